@@ -0,0 +1,145 @@
+// Command admin runs maintenance tasks against the Archer Aqua database.
+//
+// Usage:
+//
+//	admin rebuild-rollups [-user <uuid>]
+//	admin rekey-logs [-user <uuid>]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/config"
+	"github.com/AD-Archer/archer-aqua/backend/internal/db"
+	"github.com/AD-Archer/archer-aqua/backend/internal/services"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"gorm.io/gorm"
+)
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, database *gorm.DB, logger *slog.Logger, args []string) error
+}
+
+var commands = map[string]command{
+	"rebuild-rollups": {
+		usage: "rebuild-rollups [-user <uuid>]  recompute daily_rollups from hydration logs",
+		run:   rebuildRollups,
+	},
+	"rekey-logs": {
+		usage: "rekey-logs [-user <uuid>]       recompute log day keys for users whose day start changed",
+		run:   rekeyLogs,
+	},
+}
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		printUsage()
+		os.Exit(2)
+	}
+
+	if err := godotenv.Load("../.env"); err != nil {
+		logger.Info("skipping .env file load", slog.Any("error", err))
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Error("failed to load config", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	dbConn, err := db.Connect(cfg, logger)
+	if err != nil {
+		logger.Error("failed to connect to database", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	if err := cmd.run(context.Background(), dbConn, logger, os.Args[2:]); err != nil {
+		logger.Error("command failed", slog.String("command", os.Args[1]), slog.Any("error", err))
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "usage: admin <command> [flags]")
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, cmd := range commands {
+		fmt.Fprintln(os.Stderr, "  "+cmd.usage)
+	}
+}
+
+func parseUserFlag(fs *flag.FlagSet, args []string) (*uuid.UUID, error) {
+	userFlag := fs.String("user", "", "limit the command to a single user id")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if *userFlag == "" {
+		return nil, nil
+	}
+	userID, err := uuid.Parse(*userFlag)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user id: %w", err)
+	}
+	return &userID, nil
+}
+
+func rebuildRollups(ctx context.Context, database *gorm.DB, logger *slog.Logger, args []string) error {
+	userID, err := parseUserFlag(flag.NewFlagSet("rebuild-rollups", flag.ExitOnError), args)
+	if err != nil {
+		return err
+	}
+
+	rollups := services.NewRollupService(database)
+
+	if userID != nil {
+		if err := rollups.RebuildUser(ctx, *userID); err != nil {
+			return err
+		}
+		logger.Info("rebuilt daily rollups", slog.String("userId", userID.String()))
+		return nil
+	}
+
+	count, err := rollups.RebuildAll(ctx)
+	if err != nil {
+		return err
+	}
+	logger.Info("rebuilt daily rollups", slog.Int("users", count))
+	return nil
+}
+
+func rekeyLogs(ctx context.Context, database *gorm.DB, logger *slog.Logger, args []string) error {
+	userID, err := parseUserFlag(flag.NewFlagSet("rekey-logs", flag.ExitOnError), args)
+	if err != nil {
+		return err
+	}
+
+	rollups := services.NewRollupService(database)
+
+	if userID != nil {
+		if err := rollups.RekeyUserLogs(ctx, *userID); err != nil {
+			return err
+		}
+		logger.Info("rekeyed hydration logs", slog.String("userId", userID.String()))
+		return nil
+	}
+
+	count, err := rollups.RekeyPending(ctx)
+	if err != nil {
+		return err
+	}
+	logger.Info("rekeyed hydration logs", slog.Int("users", count))
+	return nil
+}
@@ -37,6 +37,8 @@ type Config struct {
 	EmailVerificationRequired bool
 	PrivacyVersion            string
 	TermsVersion              string
+	TrashRetention            time.Duration
+	TrashPurgeInterval        time.Duration
 }
 
 func Load() (Config, error) {
@@ -106,6 +108,18 @@ func Load() (Config, error) {
 	privacyVersion := valueOrDefault("PRIVACY_VERSION", "2025-10-06")
 	termsVersion := valueOrDefault("TERMS_VERSION", "2025-10-06")
 
+	trashRetention, err := time.ParseDuration(valueOrDefault("TRASH_RETENTION", "720h"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid TRASH_RETENTION duration: %w", err)
+	}
+	trashPurgeInterval, err := time.ParseDuration(valueOrDefault("TRASH_PURGE_INTERVAL", "1h"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid TRASH_PURGE_INTERVAL duration: %w", err)
+	}
+	if trashPurgeInterval <= 0 {
+		return Config{}, fmt.Errorf("TRASH_PURGE_INTERVAL must be positive")
+	}
+
 	return Config{
 		Port:                      port,
 		DatabaseURL:               databaseURL,
@@ -135,6 +149,8 @@ func Load() (Config, error) {
 		EmailVerificationRequired: emailVerificationRequired,
 		PrivacyVersion:            privacyVersion,
 		TermsVersion:              termsVersion,
+		TrashRetention:            trashRetention,
+		TrashPurgeInterval:        trashPurgeInterval,
 	}, nil
 }
 
@@ -52,6 +52,18 @@ func Connect(cfg config.Config, appLogger *slog.Logger) (*gorm.DB, error) {
 }
 
 func migrate(database *gorm.DB) error {
+	hadRollups := database.Migrator().HasTable(&models.DailyRollup{})
+	hadGoalSnapshots := database.Migrator().HasColumn(&models.DailyGoal{}, "Source")
+	hadTimezoneHistory := database.Migrator().HasTable(&models.TimezoneChange{})
+	hadGoalStrategies := database.Migrator().HasColumn(&models.User{}, "GoalStrategy")
+	hadGoalBreakdown := database.Migrator().HasColumn(&models.DailyGoal{}, "BaseGoalMl")
+	hadWeightHistory := database.Migrator().HasTable(&models.WeightMeasurement{})
+	hadDailyWeather := database.Migrator().HasTable(&models.DailyWeather{})
+
+	if err := migrateSyncSequences(database); err != nil {
+		return fmt.Errorf("migrate sync sequences: %w", err)
+	}
+
 	// Run AutoMigrate and handle column already exists errors gracefully
 	err := database.AutoMigrate(
 		&models.User{},
@@ -59,6 +71,17 @@ func migrate(database *gorm.DB) error {
 		&models.HydrationLog{},
 		&models.DailyGoal{},
 		&models.WeatherData{},
+		&models.SyncChange{},
+		&models.DailyRollup{},
+		&models.ExcusedDay{},
+		&models.TimezoneChange{},
+		&models.ExerciseSession{},
+		&models.GoalRule{},
+		&models.SafetyWarning{},
+		&models.Container{},
+		&models.WeightMeasurement{},
+		&models.SweatTest{},
+		&models.DailyWeather{},
 	)
 
 	// If the error is about columns already existing, we can ignore it
@@ -67,9 +90,181 @@ func migrate(database *gorm.DB) error {
 		return err
 	}
 
+	if !hadGoalSnapshots {
+		if err := backfillDailyGoalSnapshots(database); err != nil {
+			return fmt.Errorf("backfill daily goal snapshots: %w", err)
+		}
+	}
+
+	if !hadRollups {
+		if err := backfillDailyRollups(database); err != nil {
+			return fmt.Errorf("backfill daily rollups: %w", err)
+		}
+	}
+
+	if !hadGoalStrategies {
+		// Custom targets used to override the formula implicitly; keep those users on it.
+		if err := database.Exec("UPDATE users SET goal_strategy = 'custom' WHERE custom_goal_liters > 0").Error; err != nil {
+			return fmt.Errorf("backfill goal strategies: %w", err)
+		}
+	}
+
+	if !hadGoalBreakdown {
+		// Goals written before adjusters existed have no adjustments, so the base is the goal.
+		if err := database.Exec("UPDATE daily_goals SET base_goal_ml = goal_ml").Error; err != nil {
+			return fmt.Errorf("backfill daily goal base: %w", err)
+		}
+	}
+
+	if !hadTimezoneHistory {
+		if err := backfillTimezoneHistory(database); err != nil {
+			return fmt.Errorf("backfill timezone history: %w", err)
+		}
+	}
+
+	if !hadWeightHistory {
+		if err := backfillWeightHistory(database); err != nil {
+			return fmt.Errorf("backfill weight history: %w", err)
+		}
+	}
+
+	if !hadDailyWeather {
+		if err := backfillDailyWeather(database); err != nil {
+			return fmt.Errorf("backfill daily weather: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// migrateSyncSequences moves an existing sync journal from global IDs to per-user sequences
+// before AutoMigrate adds the unique (user_id, sequence) index. Each entry's sequence starts as
+// its ID, so cursors already held by clients stay valid.
+func migrateSyncSequences(database *gorm.DB) error {
+	migrator := database.Migrator()
+	if !migrator.HasTable(&models.SyncChange{}) || migrator.HasColumn(&models.SyncChange{}, "Sequence") {
+		return nil
+	}
+
+	if !migrator.HasColumn(&models.User{}, "SyncSequence") {
+		if err := migrator.AddColumn(&models.User{}, "SyncSequence"); err != nil {
+			return err
+		}
+	}
+	if err := migrator.AddColumn(&models.SyncChange{}, "Sequence"); err != nil {
+		return err
+	}
+	if err := database.Exec("UPDATE sync_changes SET sequence = id").Error; err != nil {
+		return err
+	}
+	return database.Exec(`
+		UPDATE users u SET sync_sequence = c.max_id
+		FROM (SELECT user_id, MAX(id) AS max_id FROM sync_changes GROUP BY user_id) c
+		WHERE c.user_id = u.id
+	`).Error
+}
+
+// backfillDailyGoalSnapshots freezes the current profile goal onto every past day with activity
+// that has no explicit goal, so existing history stops following later profile edits.
+func backfillDailyGoalSnapshots(database *gorm.DB) error {
+	return database.Exec(`
+		INSERT INTO daily_goals (id, created_at, updated_at, user_id, date, goal_ml, source)
+		SELECT gen_random_uuid(), NOW(), NOW(), l.user_id, l.daily_key,
+			COALESCE(NULLIF(MAX(u.daily_goal_liters) * 1000, 0), 2000),
+			'snapshot'
+		FROM hydration_logs l
+		JOIN users u ON u.id = l.user_id
+		WHERE l.deleted_at IS NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM daily_goals g WHERE g.user_id = l.user_id AND g.date = l.daily_key
+			)
+		GROUP BY l.user_id, l.daily_key
+	`).Error
+}
+
+// backfillDailyRollups seeds daily_rollups from existing logs the first time the table is created.
+// Afterwards the services keep it current and `admin rebuild-rollups` can recompute it. Totals
+// and statuses mirror refreshDailyRollups and dayStatus, including the 90% approaching-limit
+// share for users in fluid restriction mode.
+func backfillDailyRollups(database *gorm.DB) error {
+	return database.Exec(`
+		INSERT INTO daily_rollups (id, created_at, updated_at, user_id, daily_key, total_volume_ml, total_effective_ml,
+			total_food_ml, total_caffeine_mg, total_sugar_g, total_alcohol_units, log_count, goal_ml, status)
+		SELECT gen_random_uuid(), NOW(), NOW(), t.user_id, t.daily_key, t.total_volume_ml, t.total_effective_ml,
+			t.total_food_ml, t.total_caffeine_mg, t.total_sugar_g, t.total_alcohol_units, t.log_count, t.goal_ml,
+			CASE
+				WHEN t.restricted AND t.total_effective_ml > t.goal_ml THEN 'over_limit'
+				WHEN t.restricted AND t.total_effective_ml >= t.goal_ml * 0.9 THEN 'approaching_limit'
+				WHEN t.restricted THEN 'under_limit'
+				WHEN t.total_effective_ml >= t.goal_ml THEN 'completed'
+				WHEN t.total_effective_ml > 0 THEN 'in_progress'
+				ELSE 'not_started'
+			END
+		FROM (
+			SELECT l.user_id, l.daily_key,
+				SUM(l.volume_ml) AS total_volume_ml,
+				SUM(l.effective_ml) AS total_effective_ml,
+				COALESCE(SUM(CASE WHEN l.mass_g IS NOT NULL THEN l.effective_ml END), 0) AS total_food_ml,
+				COALESCE(SUM(l.caffeine_mg), 0) AS total_caffeine_mg,
+				COALESCE(SUM(l.sugar_g), 0) AS total_sugar_g,
+				COALESCE(SUM(l.alcohol_units), 0) AS total_alcohol_units,
+				COUNT(*) AS log_count,
+				BOOL_OR(u.fluid_restriction_enabled) AS restricted,
+				COALESCE(
+					(SELECT g.goal_ml FROM daily_goals g WHERE g.user_id = l.user_id AND g.date = l.daily_key ORDER BY g.updated_at DESC LIMIT 1),
+					NULLIF(MAX(u.daily_goal_liters) * 1000, 0),
+					2000
+				) AS goal_ml
+			FROM hydration_logs l
+			JOIN users u ON u.id = l.user_id
+			WHERE l.deleted_at IS NULL
+			GROUP BY l.user_id, l.daily_key
+		) t
+		ON CONFLICT (user_id, daily_key) DO NOTHING
+	`).Error
+}
+
+// backfillTimezoneHistory starts every existing user's history with their current timezone.
+func backfillTimezoneHistory(database *gorm.DB) error {
+	return database.Exec(`
+		INSERT INTO timezone_changes (id, created_at, user_id, timezone, previous_timezone, effective_at, source)
+		SELECT gen_random_uuid(), NOW(), u.id, u.timezone, '', COALESCE(u.timezone_last_confirmed_at, u.created_at), 'profile'
+		FROM users u
+		WHERE u.timezone <> ''
+	`).Error
+}
+
+// backfillWeightHistory starts every existing user's weight history with their profile weight,
+// dated to account creation so it covers all of their past days.
+func backfillWeightHistory(database *gorm.DB) error {
+	return database.Exec(`
+		INSERT INTO weight_measurements (id, created_at, user_id, weight_kg, unit, measured_at, timezone, daily_key, source)
+		SELECT gen_random_uuid(), NOW(), u.id, u.weight_kg, COALESCE(NULLIF(u.weight_unit, ''), 'kg'), u.created_at,
+			COALESCE(NULLIF(u.timezone, ''), 'UTC'),
+			TO_CHAR((u.created_at AT TIME ZONE COALESCE(NULLIF(u.timezone, ''), 'UTC')) - MAKE_INTERVAL(mins => u.day_start_offset_minutes), 'YYYY-MM-DD'),
+			'profile'
+		FROM users u
+		WHERE u.weight_kg > 0
+	`).Error
+}
+
+// backfillDailyWeather keeps the hottest cached reading of each day, keyed in the user's current
+// timezone, so weather bonuses already on goals survive the next recompute.
+func backfillDailyWeather(database *gorm.DB) error {
+	return database.Exec(`
+		INSERT INTO daily_weathers (id, created_at, updated_at, user_id, daily_key, temperature, feels_like, humidity, fetched_at)
+		SELECT DISTINCT ON (t.user_id, t.daily_key)
+			gen_random_uuid(), NOW(), NOW(), t.user_id, t.daily_key, t.temperature, t.feels_like, t.humidity, t.fetched_at
+		FROM (
+			SELECT w.user_id, w.temperature, w.feels_like, w.humidity, w.fetched_at,
+				TO_CHAR((w.fetched_at AT TIME ZONE COALESCE(NULLIF(u.timezone, ''), 'UTC')) - MAKE_INTERVAL(mins => u.day_start_offset_minutes), 'YYYY-MM-DD') AS daily_key
+			FROM weather_data w
+			JOIN users u ON u.id = w.user_id
+		) t
+		ORDER BY t.user_id, t.daily_key, GREATEST(t.temperature, t.feels_like) DESC
+	`).Error
+}
+
 // isColumnExistsError checks if the error is about a column already existing
 func isColumnExistsError(err error) bool {
 	errStr := err.Error()
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CaffeinePoint struct {
+	At       time.Time `json:"at"`
+	ActiveMg float64   `json:"activeMg"`
+}
+
+// CaffeineCurveResponse models the caffeine still active across one day, including what is
+// left over from the day before.
+type CaffeineCurveResponse struct {
+	Date              string          `json:"date"`
+	Timezone          string          `json:"timezone"`
+	HalfLifeMinutes   int             `json:"halfLifeMinutes"`
+	StepMinutes       int             `json:"stepMinutes"`
+	ConsumedMg        float64         `json:"consumedMg"`
+	PeakMg            float64         `json:"peakMg"`
+	PeakAt            *time.Time      `json:"peakAt"`
+	BedtimeAt         time.Time       `json:"bedtimeAt"`
+	ActiveAtBedtimeMg float64         `json:"activeAtBedtimeMg"`
+	BedtimeLimitMg    float64         `json:"bedtimeLimitMg"`
+	Points            []CaffeinePoint `json:"points"`
+}
+
+// CaffeineBedtimeWarning is attached to a new log that leaves more than the user's bedtime
+// limit of caffeine active when they go to sleep.
+type CaffeineBedtimeWarning struct {
+	HydrationLogID    uuid.UUID `json:"hydrationLogId"`
+	BedtimeAt         time.Time `json:"bedtimeAt"`
+	ActiveAtBedtimeMg float64   `json:"activeAtBedtimeMg"`
+	BedtimeLimitMg    float64   `json:"bedtimeLimitMg"`
+}
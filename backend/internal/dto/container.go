@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type CreateContainerRequest struct {
+	Name           string        `json:"name"`
+	Capacity       VolumePayload `json:"capacity"`
+	ColorHex       *string       `json:"colorHex"`
+	DefaultDrinkID *uuid.UUID    `json:"defaultDrinkId"`
+}
+
+type UpdateContainerRequest struct {
+	Name              *string        `json:"name"`
+	Capacity          *VolumePayload `json:"capacity"`
+	ColorHex          *string        `json:"colorHex"`
+	DefaultDrinkID    *uuid.UUID     `json:"defaultDrinkId"`
+	ClearDefaultDrink bool           `json:"clearDefaultDrink"`
+	Archived          *bool          `json:"archived"`
+}
+
+// FillContainerRequest sets a container's fill level. A nil Volume fills it to capacity.
+type FillContainerRequest struct {
+	Volume *VolumePayload `json:"volume"`
+}
+
+// ContainerSipRequest logs part of a container's capacity, given as exactly one of Fraction
+// (0–1] or Percent (0–100]. DrinkID defaults to the container's default drink.
+type ContainerSipRequest struct {
+	Fraction       *float64   `json:"fraction"`
+	Percent        *float64   `json:"percent"`
+	DrinkID        *uuid.UUID `json:"drinkId"`
+	ConsumedAt     time.Time  `json:"consumedAt"`
+	Timezone       string     `json:"timezone"`
+	Source         string     `json:"source"`
+	Notes          *string    `json:"notes"`
+	IdempotencyKey *string    `json:"idempotencyKey"`
+}
+
+type ContainerSipResponse struct {
+	Log       HydrationLogResponse `json:"log"`
+	Container ContainerResponse    `json:"container"`
+}
+
+type ContainerResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	Name           string     `json:"name"`
+	CapacityMl     float64    `json:"capacityMl"`
+	ColorHex       *string    `json:"colorHex"`
+	DefaultDrinkID *uuid.UUID `json:"defaultDrinkId"`
+	CurrentFillMl  float64    `json:"currentFillMl"`
+	FillPercentage float64    `json:"fillPercentage"`
+	FilledAt       *time.Time `json:"filledAt"`
+	ArchivedAt     *time.Time `json:"archivedAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+func NewContainerResponse(container models.Container) ContainerResponse {
+	fillPercentage := 0.0
+	if container.CapacityMl > 0 {
+		fillPercentage = container.CurrentFillMl / container.CapacityMl * 100
+	}
+	return ContainerResponse{
+		ID:             container.ID,
+		Name:           container.Name,
+		CapacityMl:     container.CapacityMl,
+		ColorHex:       container.ColorHex,
+		DefaultDrinkID: container.DefaultDrinkID,
+		CurrentFillMl:  container.CurrentFillMl,
+		FillPercentage: fillPercentage,
+		FilledAt:       container.FilledAt,
+		ArchivedAt:     container.ArchivedAt,
+		CreatedAt:      container.CreatedAt,
+		UpdatedAt:      container.UpdatedAt,
+	}
+}
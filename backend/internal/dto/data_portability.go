@@ -10,10 +10,22 @@ type UserDataExport struct {
 	User            UserResponse           `json:"user"`
 	Drinks          []DrinkResponse        `json:"drinks"`
 	HydrationLogs   []HydrationLogResponse `json:"hydrationLogs"`
+	DailyTotals     []DailyTotalExport     `json:"dailyTotals"`
 	ExportedAt      time.Time              `json:"exportedAt"`
 	PoliciesVersion string                 `json:"policiesVersion"`
 }
 
+// DailyTotalExport is one tracked day in an export. OverLimit flags days above the fluid limit
+// for users in restriction mode.
+type DailyTotalExport struct {
+	Date             string  `json:"date"`
+	TotalVolumeMl    float64 `json:"totalVolumeMl"`
+	TotalEffectiveMl float64 `json:"totalEffectiveMl"`
+	GoalMl           float64 `json:"goalMl"`
+	Status           string  `json:"status"`
+	OverLimit        bool    `json:"overLimit"`
+}
+
 type DrinkImport struct {
 	ID                  uuid.UUID  `json:"id"`
 	Name                string     `json:"name"`
@@ -40,6 +52,7 @@ type HydrationLogImport struct {
 	DailyKey            string     `json:"dailyKey"`
 	Source              string     `json:"source"`
 	Notes               *string    `json:"notes"`
+	IdempotencyKey      *string    `json:"idempotencyKey"`
 }
 
 type UserDataImportRequest struct {
@@ -12,50 +12,72 @@ type VolumePayload struct {
 	Unit  string  `json:"unit"`
 }
 
+// MassPayload is a food portion; Unit is one of g, kg, oz or lb.
+type MassPayload struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
 type CreateDrinkRequest struct {
-	Name                string         `json:"name"`
-	Type                string         `json:"type"`
-	HydrationMultiplier float64        `json:"hydrationMultiplier"`
-	DefaultVolume       *VolumePayload `json:"defaultVolume"`
-	ColorHex            *string        `json:"colorHex"`
-	Source              string         `json:"source"`
+	Name                 string         `json:"name"`
+	Type                 string         `json:"type"`
+	HydrationMultiplier  float64        `json:"hydrationMultiplier"`
+	DefaultVolume        *VolumePayload `json:"defaultVolume"`
+	ColorHex             *string        `json:"colorHex"`
+	Source               string         `json:"source"`
+	WaterContentRatio    float64        `json:"waterContentRatio"`
+	CaffeineMgPer100Ml   float64        `json:"caffeineMgPer100Ml"`
+	SugarGPer100Ml       float64        `json:"sugarGPer100Ml"`
+	AlcoholUnitsPer100Ml float64        `json:"alcoholUnitsPer100Ml"`
 }
 
 type UpdateDrinkRequest struct {
-	Name                *string        `json:"name"`
-	Type                *string        `json:"type"`
-	HydrationMultiplier *float64       `json:"hydrationMultiplier"`
-	DefaultVolume       *VolumePayload `json:"defaultVolume"`
-	ColorHex            *string        `json:"colorHex"`
-	Archived            *bool          `json:"archived"`
+	Name                 *string        `json:"name"`
+	Type                 *string        `json:"type"`
+	HydrationMultiplier  *float64       `json:"hydrationMultiplier"`
+	DefaultVolume        *VolumePayload `json:"defaultVolume"`
+	ColorHex             *string        `json:"colorHex"`
+	Archived             *bool          `json:"archived"`
+	WaterContentRatio    *float64       `json:"waterContentRatio"`
+	CaffeineMgPer100Ml   *float64       `json:"caffeineMgPer100Ml"`
+	SugarGPer100Ml       *float64       `json:"sugarGPer100Ml"`
+	AlcoholUnitsPer100Ml *float64       `json:"alcoholUnitsPer100Ml"`
 }
 
 type DrinkResponse struct {
-	ID                  uuid.UUID  `json:"id"`
-	UserID              *uuid.UUID `json:"userId"`
-	Name                string     `json:"name"`
-	Type                string     `json:"type"`
-	HydrationMultiplier float64    `json:"hydrationMultiplier"`
-	DefaultVolumeMl     *float64   `json:"defaultVolumeMl"`
-	ColorHex            *string    `json:"colorHex"`
-	Source              string     `json:"source"`
-	ArchivedAt          *time.Time `json:"archivedAt"`
-	CreatedAt           time.Time  `json:"createdAt"`
-	UpdatedAt           time.Time  `json:"updatedAt"`
+	ID                   uuid.UUID  `json:"id"`
+	UserID               *uuid.UUID `json:"userId"`
+	Name                 string     `json:"name"`
+	Type                 string     `json:"type"`
+	HydrationMultiplier  float64    `json:"hydrationMultiplier"`
+	DefaultVolumeMl      *float64   `json:"defaultVolumeMl"`
+	ColorHex             *string    `json:"colorHex"`
+	Source               string     `json:"source"`
+	WaterContentRatio    float64    `json:"waterContentRatio"`
+	CaffeineMgPer100Ml   float64    `json:"caffeineMgPer100Ml"`
+	SugarGPer100Ml       float64    `json:"sugarGPer100Ml"`
+	AlcoholUnitsPer100Ml float64    `json:"alcoholUnitsPer100Ml"`
+	ArchivedAt           *time.Time `json:"archivedAt"`
+	CreatedAt            time.Time  `json:"createdAt"`
+	UpdatedAt            time.Time  `json:"updatedAt"`
 }
 
 func NewDrinkResponse(drink models.Drink) DrinkResponse {
 	return DrinkResponse{
-		ID:                  drink.ID,
-		UserID:              drink.UserID,
-		Name:                drink.Name,
-		Type:                drink.Type,
-		HydrationMultiplier: drink.HydrationMultiplier,
-		DefaultVolumeMl:     drink.DefaultVolumeMl,
-		ColorHex:            drink.ColorHex,
-		Source:              drink.Source,
-		ArchivedAt:          drink.ArchivedAt,
-		CreatedAt:           drink.CreatedAt,
-		UpdatedAt:           drink.UpdatedAt,
+		ID:                   drink.ID,
+		UserID:               drink.UserID,
+		Name:                 drink.Name,
+		Type:                 drink.Type,
+		HydrationMultiplier:  drink.HydrationMultiplier,
+		DefaultVolumeMl:      drink.DefaultVolumeMl,
+		ColorHex:             drink.ColorHex,
+		Source:               drink.Source,
+		WaterContentRatio:    drink.WaterContentRatio,
+		CaffeineMgPer100Ml:   drink.CaffeineMgPer100Ml,
+		SugarGPer100Ml:       drink.SugarGPer100Ml,
+		AlcoholUnitsPer100Ml: drink.AlcoholUnitsPer100Ml,
+		ArchivedAt:           drink.ArchivedAt,
+		CreatedAt:            drink.CreatedAt,
+		UpdatedAt:            drink.UpdatedAt,
 	}
 }
@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type CreateExerciseSessionRequest struct {
+	Type            string     `json:"type"`
+	StartedAt       *time.Time `json:"startedAt"`
+	DurationMinutes int        `json:"durationMinutes"`
+	Intensity       string     `json:"intensity"`
+	SweatLossMl     *float64   `json:"sweatLossMl"`
+	Timezone        string     `json:"timezone"`
+	Notes           *string    `json:"notes"`
+}
+
+type UpdateExerciseSessionRequest struct {
+	Type            *string    `json:"type"`
+	StartedAt       *time.Time `json:"startedAt"`
+	DurationMinutes *int       `json:"durationMinutes"`
+	Intensity       *string    `json:"intensity"`
+	SweatLossMl     *float64   `json:"sweatLossMl"`
+	ClearSweatLoss  bool       `json:"clearSweatLoss"`
+	Timezone        *string    `json:"timezone"`
+	Notes           *string    `json:"notes"`
+}
+
+type ExerciseSessionResponse struct {
+	ID              uuid.UUID `json:"id"`
+	Type            string    `json:"type"`
+	StartedAt       time.Time `json:"startedAt"`
+	DurationMinutes int       `json:"durationMinutes"`
+	Intensity       string    `json:"intensity"`
+	SweatLossMl     *float64  `json:"sweatLossMl"`
+	Timezone        string    `json:"timezone"`
+	DailyKey        string    `json:"dailyKey"`
+	Notes           *string   `json:"notes"`
+}
+
+func NewExerciseSessionResponse(session models.ExerciseSession) ExerciseSessionResponse {
+	return ExerciseSessionResponse{
+		ID:              session.ID,
+		Type:            session.Type,
+		StartedAt:       session.StartedAt,
+		DurationMinutes: session.DurationMinutes,
+		Intensity:       session.Intensity,
+		SweatLossMl:     session.SweatLossMl,
+		Timezone:        session.Timezone,
+		DailyKey:        session.DailyKey,
+		Notes:           session.Notes,
+	}
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// GoalRuleRequest creates or replaces a recurring goal rule. Weekdays use three-letter or full
+// names ("mon" or "monday", case-insensitive); an empty list matches every day.
+type GoalRuleRequest struct {
+	Name      string   `json:"name"`
+	GoalMl    float64  `json:"goalMl"`
+	Weekdays  []string `json:"weekdays"`
+	StartDate *string  `json:"startDate"`
+	EndDate   *string  `json:"endDate"`
+	Priority  int      `json:"priority"`
+	Enabled   *bool    `json:"enabled"`
+}
+
+type GoalRuleResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	GoalMl    float64   `json:"goalMl"`
+	Weekdays  []string  `json:"weekdays"`
+	StartDate *string   `json:"startDate"`
+	EndDate   *string   `json:"endDate"`
+	Priority  int       `json:"priority"`
+	Enabled   bool      `json:"enabled"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// WeekdayNames maps time.Weekday to the names used by goal rules.
+var WeekdayNames = [7]string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+func NewGoalRuleResponse(rule models.GoalRule) GoalRuleResponse {
+	weekdays := make([]string, 0, 7)
+	for day, name := range WeekdayNames {
+		if rule.Weekdays&(1<<day) != 0 {
+			weekdays = append(weekdays, name)
+		}
+	}
+
+	return GoalRuleResponse{
+		ID:        rule.ID,
+		Name:      rule.Name,
+		GoalMl:    rule.GoalMl,
+		Weekdays:  weekdays,
+		StartDate: rule.StartDate,
+		EndDate:   rule.EndDate,
+		Priority:  rule.Priority,
+		Enabled:   rule.Enabled,
+		UpdatedAt: rule.UpdatedAt,
+	}
+}
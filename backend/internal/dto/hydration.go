@@ -5,44 +5,220 @@ import (
 
 	"github.com/AD-Archer/archer-aqua/backend/internal/models"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type LogHydrationRequest struct {
 	DrinkID             *uuid.UUID    `json:"drinkId"`
+	ContainerID         *uuid.UUID    `json:"containerId"`
 	Label               string        `json:"label"`
 	Volume              VolumePayload `json:"volume"`
+	Mass                *MassPayload  `json:"mass"`
+	WaterContentRatio   *float64      `json:"waterContentRatio"`
 	HydrationMultiplier *float64      `json:"hydrationMultiplier"`
 	ConsumedAt          time.Time     `json:"consumedAt"`
 	Timezone            string        `json:"timezone"`
 	Source              string        `json:"source"`
 	Notes               *string       `json:"notes"`
+	IdempotencyKey      *string       `json:"idempotencyKey"`
+}
+
+type LogHydrationBatchRequest struct {
+	Logs []LogHydrationRequest `json:"logs"`
+}
+
+// Batch item statuses reported by HydrationLogBatchResult.
+const (
+	BatchStatusCreated  = "created"
+	BatchStatusExisting = "existing"
+	BatchStatusFailed   = "failed"
+)
+
+type HydrationLogBatchResult struct {
+	Index          int                   `json:"index"`
+	IdempotencyKey *string               `json:"idempotencyKey"`
+	Status         string                `json:"status"`
+	Log            *HydrationLogResponse `json:"log,omitempty"`
+	Error          string                `json:"error,omitempty"`
+}
+
+type LogHydrationBatchResponse struct {
+	Results []HydrationLogBatchResult `json:"results"`
+}
+
+type UpdateHydrationLogRequest struct {
+	DrinkID             *uuid.UUID     `json:"drinkId"`
+	ContainerID         *uuid.UUID     `json:"containerId"`
+	ClearContainer      bool           `json:"clearContainer"`
+	Label               *string        `json:"label"`
+	Volume              *VolumePayload `json:"volume"`
+	Mass                *MassPayload   `json:"mass"`
+	WaterContentRatio   *float64       `json:"waterContentRatio"`
+	HydrationMultiplier *float64       `json:"hydrationMultiplier"`
+	ConsumedAt          *time.Time     `json:"consumedAt"`
+	Timezone            *string        `json:"timezone"`
+	Notes               *string        `json:"notes"`
 }
 
 type HydrationLogResponse struct {
-	ID                  uuid.UUID  `json:"id"`
-	UserID              uuid.UUID  `json:"userId"`
-	DrinkID             *uuid.UUID `json:"drinkId"`
-	Label               string     `json:"label"`
-	VolumeMl            float64    `json:"volumeMl"`
-	HydrationMultiplier float64    `json:"hydrationMultiplier"`
-	EffectiveMl         float64    `json:"effectiveMl"`
-	ConsumedAt          time.Time  `json:"consumedAt"`
-	ConsumedAtLocal     time.Time  `json:"consumedAtLocal"`
-	Timezone            string     `json:"timezone"`
-	DailyKey            string     `json:"dailyKey"`
-	Source              string     `json:"source"`
-	Notes               *string    `json:"notes"`
+	ID                  uuid.UUID               `json:"id"`
+	UserID              uuid.UUID               `json:"userId"`
+	DrinkID             *uuid.UUID              `json:"drinkId"`
+	ContainerID         *uuid.UUID              `json:"containerId"`
+	SweatTestID         *uuid.UUID              `json:"sweatTestId"`
+	Label               string                  `json:"label"`
+	VolumeMl            float64                 `json:"volumeMl"`
+	HydrationMultiplier float64                 `json:"hydrationMultiplier"`
+	EffectiveMl         float64                 `json:"effectiveMl"`
+	MassG               *float64                `json:"massG"`
+	WaterContentRatio   *float64                `json:"waterContentRatio"`
+	CaffeineMg          float64                 `json:"caffeineMg"`
+	SugarG              float64                 `json:"sugarG"`
+	AlcoholUnits        float64                 `json:"alcoholUnits"`
+	ConsumedAt          time.Time               `json:"consumedAt"`
+	ConsumedAtLocal     time.Time               `json:"consumedAtLocal"`
+	Timezone            string                  `json:"timezone"`
+	DailyKey            string                  `json:"dailyKey"`
+	Source              string                  `json:"source"`
+	Notes               *string                 `json:"notes"`
+	IdempotencyKey      *string                 `json:"idempotencyKey"`
+	DeletedAt           *time.Time              `json:"deletedAt,omitempty"`
+	Warnings            []SafetyWarningResponse `json:"warnings,omitempty"`
+	CaffeineWarning     *CaffeineBedtimeWarning `json:"caffeineWarning,omitempty"`
+}
+
+// HydrationLogQuery filters and pages through a user's hydration history.
+// From and To are inclusive YYYY-MM-DD daily keys.
+type HydrationLogQuery struct {
+	From        string
+	To          string
+	DrinkID     *uuid.UUID
+	Source      string
+	Label       string
+	MinVolumeMl *float64
+	MaxVolumeMl *float64
+	Cursor      string
+	Limit       int
+}
+
+type HydrationLogPageResponse struct {
+	Logs       []HydrationLogResponse `json:"logs"`
+	NextCursor *string                `json:"nextCursor"`
 }
 
 type DailySummaryResponse struct {
-	Date               string                 `json:"date"`
-	Timezone           string                 `json:"timezone"`
-	TotalVolumeMl      float64                `json:"totalVolumeMl"`
-	TotalEffectiveMl   float64                `json:"totalEffectiveMl"`
-	GoalVolumeMl       float64                `json:"goalVolumeMl"`
-	ProgressPercentage float64                `json:"progressPercentage"`
-	Status             string                 `json:"status"`
-	Logs               []HydrationLogResponse `json:"logs"`
+	Date               string                    `json:"date"`
+	Timezone           string                    `json:"timezone"`
+	TotalVolumeMl      float64                   `json:"totalVolumeMl"`
+	TotalEffectiveMl   float64                   `json:"totalEffectiveMl"`
+	FromFoodMl         float64                   `json:"fromFoodMl"`
+	GoalVolumeMl       float64                   `json:"goalVolumeMl"`
+	ProgressPercentage float64                   `json:"progressPercentage"`
+	Status             string                    `json:"status"`
+	Pace               *HydrationPaceResponse    `json:"pace,omitempty"`
+	GoalBreakdown      []GoalBreakdownItem       `json:"goalBreakdown"`
+	Logs               []HydrationLogResponse    `json:"logs"`
+	Exercise           []ExerciseSessionResponse `json:"exercise"`
+	Containers         []ContainerResponse       `json:"containers"`
+	Nutrients          NutrientTotals            `json:"nutrients"`
+	NutrientWarnings   []NutrientWarning         `json:"nutrientWarnings"`
+}
+
+// NutrientTotals sums the nutrients of the logs in a day or stats bucket.
+type NutrientTotals struct {
+	CaffeineMg   float64 `json:"caffeineMg"`
+	SugarG       float64 `json:"sugarG"`
+	AlcoholUnits float64 `json:"alcoholUnits"`
+}
+
+// NutrientWarning flags a day whose caffeine or alcohol total reached the user's daily limit.
+// Status is "approaching_limit" or "over_limit".
+type NutrientWarning struct {
+	Date     string  `json:"date"`
+	Nutrient string  `json:"nutrient"`
+	Total    float64 `json:"total"`
+	Limit    float64 `json:"limit"`
+	Status   string  `json:"status"`
+}
+
+// Goal breakdown components.
+const (
+	GoalComponentBase     = "base"
+	GoalComponentActivity = "activity"
+	GoalComponentManual   = "manual"
+	GoalComponentSchedule = "schedule"
+)
+
+// GoalBreakdownItem explains part of a day's goal. Component groups the line (base, activity,
+// schedule, weather, exercise, manual); Source names what produced it, such as a goal strategy.
+type GoalBreakdownItem struct {
+	Component string                 `json:"component"`
+	Reason    string                 `json:"reason"`
+	Source    string                 `json:"source"`
+	AmountMl  float64                `json:"amountMl"`
+	Inputs    map[string]interface{} `json:"inputs,omitempty"`
+}
+
+// NewGoalBreakdown lists the base goal's components followed by its adjustments.
+func NewGoalBreakdown(goal models.DailyGoal) []GoalBreakdownItem {
+	items := make([]GoalBreakdownItem, 0, len(goal.BaseComponents)+len(goal.Adjustments)+1)
+
+	if len(goal.BaseComponents) == 0 {
+		// Goals stored before breakdowns were recorded.
+		component, reason := GoalComponentBase, "base"
+		if goal.Source == models.DailyGoalSourceManual {
+			component, reason = GoalComponentManual, "manual_override"
+		}
+		amount := goal.BaseGoalMl
+		if amount == 0 && len(goal.Adjustments) == 0 {
+			amount = goal.GoalMl
+		}
+		items = append(items, GoalBreakdownItem{Component: component, Reason: reason, Source: goal.Source, AmountMl: amount})
+	}
+
+	for _, line := range goal.BaseComponents {
+		component := GoalComponentBase
+		switch {
+		case line.Source == models.DailyGoalSourceManual:
+			component = GoalComponentManual
+		case line.Source == models.GoalRuleSource:
+			component = GoalComponentSchedule
+		case line.Reason == GoalComponentActivity:
+			component = GoalComponentActivity
+		}
+		items = append(items, GoalBreakdownItem{
+			Component: component,
+			Reason:    line.Reason,
+			Source:    line.Source,
+			AmountMl:  line.AmountMl,
+			Inputs:    line.Inputs,
+		})
+	}
+
+	for _, adjustment := range goal.Adjustments {
+		items = append(items, GoalBreakdownItem{
+			Component: adjustment.Source,
+			Reason:    adjustment.Reason,
+			Source:    adjustment.Source,
+			AmountMl:  adjustment.AmountMl,
+			Inputs:    adjustment.Inputs,
+		})
+	}
+
+	return items
+}
+
+// HydrationPaceResponse compares intake so far with an even spread of the goal across the
+// user's wake window. Only present on today's summary.
+type HydrationPaceResponse struct {
+	AsOf                  time.Time `json:"asOf"`
+	WakeAt                time.Time `json:"wakeAt"`
+	SleepAt               time.Time `json:"sleepAt"`
+	ExpectedEffectiveMl   float64   `json:"expectedEffectiveMl"`
+	ActualEffectiveMl     float64   `json:"actualEffectiveMl"`
+	DifferenceMl          float64   `json:"differenceMl"`
+	Status                string    `json:"status"`
+	RecommendedNextHourMl float64   `json:"recommendedNextHourMl"`
 }
 
 type SetDailyGoalRequest struct {
@@ -51,36 +227,98 @@ type SetDailyGoalRequest struct {
 }
 
 type DailyGoalResponse struct {
-	ID     string  `json:"id"`
-	UserID string  `json:"userId"`
-	Date   string  `json:"date"`
-	GoalMl float64 `json:"goalMl"`
+	ID          string                  `json:"id"`
+	UserID      string                  `json:"userId"`
+	Date        string                  `json:"date"`
+	GoalMl      float64                 `json:"goalMl"`
+	BaseGoalMl  float64                 `json:"baseGoalMl"`
+	Adjustments []GoalAdjustmentPayload `json:"adjustments"`
+	Source      string                  `json:"source"`
+}
+
+// GoalAdjustmentPayload is one line of a goal breakdown, e.g. +400 ml for heat.
+type GoalAdjustmentPayload struct {
+	Source   string                 `json:"source"`
+	Reason   string                 `json:"reason"`
+	AmountMl float64                `json:"amountMl"`
+	Inputs   map[string]interface{} `json:"inputs,omitempty"`
+}
+
+func NewGoalAdjustmentPayloads(adjustments []models.GoalAdjustment) []GoalAdjustmentPayload {
+	payloads := make([]GoalAdjustmentPayload, 0, len(adjustments))
+	for _, adjustment := range adjustments {
+		payloads = append(payloads, GoalAdjustmentPayload(adjustment))
+	}
+	return payloads
 }
 
 type HydrationStatsResponse struct {
 	UserID           uuid.UUID              `json:"userId"`
 	Timezone         string                 `json:"timezone"`
+	Interval         string                 `json:"interval"`
 	DailySummaries   []DailySummaryResponse `json:"dailySummaries"`
 	StreakCount      int                    `json:"streakCount"`
 	BestStreak       int                    `json:"bestStreak"`
 	TotalVolumeMl    float64                `json:"totalVolumeMl"`
 	TotalEffectiveMl float64                `json:"totalEffectiveMl"`
+	TotalFromFoodMl  float64                `json:"totalFromFoodMl"`
+}
+
+func NewDailyGoalResponse(goal models.DailyGoal) DailyGoalResponse {
+	return DailyGoalResponse{
+		ID:          goal.ID.String(),
+		UserID:      goal.UserID.String(),
+		Date:        goal.Date,
+		GoalMl:      goal.GoalMl,
+		BaseGoalMl:  goal.BaseGoalMl,
+		Adjustments: NewGoalAdjustmentPayloads(goal.Adjustments),
+		Source:      goal.Source,
+	}
 }
 
 func NewHydrationLogResponse(log models.HydrationLog) HydrationLogResponse {
-	return HydrationLogResponse{
+	response := HydrationLogResponse{
 		ID:                  log.ID,
 		UserID:              log.UserID,
 		DrinkID:             log.DrinkID,
+		ContainerID:         log.ContainerID,
+		SweatTestID:         log.SweatTestID,
 		Label:               log.Label,
 		VolumeMl:            log.VolumeMl,
 		HydrationMultiplier: log.HydrationMultiplier,
 		EffectiveMl:         log.EffectiveMl,
+		MassG:               log.MassG,
+		WaterContentRatio:   log.WaterContentRatio,
+		CaffeineMg:          log.CaffeineMg,
+		SugarG:              log.SugarG,
+		AlcoholUnits:        log.AlcoholUnits,
 		ConsumedAt:          log.ConsumedAt,
 		ConsumedAtLocal:     log.ConsumedAtLocal,
 		Timezone:            log.Timezone,
 		DailyKey:            log.DailyKey,
 		Source:              log.Source,
 		Notes:               log.Notes,
+		IdempotencyKey:      log.IdempotencyKey,
+		DeletedAt:           deletedAtPtr(log.DeletedAt),
+	}
+	for _, warning := range log.SafetyWarnings {
+		response.Warnings = append(response.Warnings, NewSafetyWarningResponse(warning))
+	}
+	if log.CaffeineWarning != nil {
+		response.CaffeineWarning = &CaffeineBedtimeWarning{
+			HydrationLogID:    log.ID,
+			BedtimeAt:         log.CaffeineWarning.BedtimeAt,
+			ActiveAtBedtimeMg: log.CaffeineWarning.ActiveAtBedtimeMg,
+			BedtimeLimitMg:    log.CaffeineWarning.BedtimeLimitMg,
+		}
+	}
+	return response
+}
+
+func deletedAtPtr(deletedAt gorm.DeletedAt) *time.Time {
+	if !deletedAt.Valid {
+		return nil
 	}
+	value := deletedAt.Time
+	return &value
 }
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type SafetyWarningResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	HydrationLogID *uuid.UUID `json:"hydrationLogId"`
+	DailyKey       string     `json:"dailyKey"`
+	TriggeredAt    time.Time  `json:"triggeredAt"`
+	Window         string     `json:"window"`
+	Severity       string     `json:"severity"`
+	IntakeMl       float64    `json:"intakeMl"`
+	ThresholdMl    float64    `json:"thresholdMl"`
+	WeightKg       float64    `json:"weightKg"`
+	Message        string     `json:"message"`
+}
+
+func NewSafetyWarningResponse(warning models.SafetyWarning) SafetyWarningResponse {
+	return SafetyWarningResponse{
+		ID:             warning.ID,
+		HydrationLogID: warning.HydrationLogID,
+		DailyKey:       warning.DailyKey,
+		TriggeredAt:    warning.TriggeredAt,
+		Window:         warning.Window,
+		Severity:       warning.Severity,
+		IntakeMl:       warning.IntakeMl,
+		ThresholdMl:    warning.ThresholdMl,
+		WeightKg:       warning.WeightKg,
+		Message:        warning.Message,
+	}
+}
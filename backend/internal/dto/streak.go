@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type StreakResponse struct {
+	CurrentStreak    int      `json:"currentStreak"`
+	BestStreak       int      `json:"bestStreak"`
+	TodayStatus      string   `json:"todayStatus"`
+	FreezesAvailable int      `json:"freezesAvailable"`
+	FrozenDays       []string `json:"frozenDays"`
+	ExcusedDays      []string `json:"excusedDays"`
+}
+
+type ExcusedDayRequest struct {
+	Date   string  `json:"date"`
+	Reason string  `json:"reason"`
+	Note   *string `json:"note"`
+}
+
+type ExcusedDayResponse struct {
+	ID     uuid.UUID `json:"id"`
+	Date   string    `json:"date"`
+	Reason string    `json:"reason"`
+	Note   *string   `json:"note"`
+}
+
+func NewExcusedDayResponse(day models.ExcusedDay) ExcusedDayResponse {
+	return ExcusedDayResponse{
+		ID:     day.ID,
+		Date:   day.Date,
+		Reason: day.Reason,
+		Note:   day.Note,
+	}
+}
@@ -0,0 +1,92 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/AD-Archer/archer-aqua/backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+// CreateSweatTestRequest records a sweat test. HydrationLogIDs are the logs drunk during the
+// session; their volume is the fluid intake. Urine is optional.
+type CreateSweatTestRequest struct {
+	Activity        string         `json:"activity"`
+	StartedAt       *time.Time     `json:"startedAt"`
+	DurationMinutes int            `json:"durationMinutes"`
+	Timezone        string         `json:"timezone"`
+	PreWeight       WeightPayload  `json:"preWeight"`
+	PostWeight      WeightPayload  `json:"postWeight"`
+	Urine           *VolumePayload `json:"urine"`
+	HydrationLogIDs []uuid.UUID    `json:"hydrationLogIds"`
+	TemperatureC    *float64       `json:"temperatureC"`
+	HumidityPercent *float64       `json:"humidityPercent"`
+	Notes           *string        `json:"notes"`
+}
+
+type SweatTestResponse struct {
+	ID                   uuid.UUID   `json:"id"`
+	Activity             string      `json:"activity"`
+	StartedAt            time.Time   `json:"startedAt"`
+	DurationMinutes      int         `json:"durationMinutes"`
+	Timezone             string      `json:"timezone"`
+	DailyKey             string      `json:"dailyKey"`
+	PreWeight            float64     `json:"preWeight"`
+	PostWeight           float64     `json:"postWeight"`
+	WeightUnit           string      `json:"weightUnit"`
+	FluidIntakeMl        float64     `json:"fluidIntakeMl"`
+	UrineMl              float64     `json:"urineMl"`
+	SweatLossMl          float64     `json:"sweatLossMl"`
+	SweatRateLPerHour    float64     `json:"sweatRateLPerHour"`
+	BodyMassLossPercent  float64     `json:"bodyMassLossPercent"`
+	ReplacementMlPerHour float64     `json:"replacementMlPerHour"`
+	HydrationLogIDs      []uuid.UUID `json:"hydrationLogIds"`
+	TemperatureC         *float64    `json:"temperatureC"`
+	HumidityPercent      *float64    `json:"humidityPercent"`
+	Notes                *string     `json:"notes"`
+}
+
+// NewSweatTestResponse expects HydrationLogs to be preloaded. replacementMlPerHour is the
+// recommended intake during similar sessions, which the service derives from the sweat rate.
+func NewSweatTestResponse(test models.SweatTest, replacementMlPerHour float64) SweatTestResponse {
+	preWeight, postWeight, unit := test.PreWeightKg, test.PostWeightKg, "kg"
+	if test.WeightUnit != "" {
+		if converted, err := utils.ConvertWeightFromKg(test.PreWeightKg, test.WeightUnit); err == nil {
+			preWeight = converted
+			postWeight, _ = utils.ConvertWeightFromKg(test.PostWeightKg, test.WeightUnit)
+			unit = test.WeightUnit
+		}
+	}
+
+	massLossPercent := 0.0
+	if test.PreWeightKg > 0 {
+		massLossPercent = (test.PreWeightKg - test.PostWeightKg) / test.PreWeightKg * 100
+	}
+
+	logIDs := make([]uuid.UUID, 0, len(test.HydrationLogs))
+	for _, log := range test.HydrationLogs {
+		logIDs = append(logIDs, log.ID)
+	}
+
+	return SweatTestResponse{
+		ID:                   test.ID,
+		Activity:             test.Activity,
+		StartedAt:            test.StartedAt,
+		DurationMinutes:      test.DurationMinutes,
+		Timezone:             test.Timezone,
+		DailyKey:             test.DailyKey,
+		PreWeight:            preWeight,
+		PostWeight:           postWeight,
+		WeightUnit:           unit,
+		FluidIntakeMl:        test.FluidIntakeMl,
+		UrineMl:              test.UrineMl,
+		SweatLossMl:          test.SweatLossMl,
+		SweatRateLPerHour:    test.SweatRateLPerHour,
+		BodyMassLossPercent:  massLossPercent,
+		ReplacementMlPerHour: replacementMlPerHour,
+		HydrationLogIDs:      logIDs,
+		TemperatureC:         test.TemperatureC,
+		HumidityPercent:      test.HumidityPercent,
+		Notes:                test.Notes,
+	}
+}
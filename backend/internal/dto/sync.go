@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SyncTombstone tells a client that an entity it may have cached was deleted.
+type SyncTombstone struct {
+	EntityType string    `json:"entityType"`
+	ID         uuid.UUID `json:"id"`
+	DeletedAt  time.Time `json:"deletedAt"`
+}
+
+// SyncResponse carries every change after the requested cursor.
+// When the request had no cursor the payload is a full snapshot and Tombstones is empty.
+type SyncResponse struct {
+	Cursor        string                 `json:"cursor"`
+	HasMore       bool                   `json:"hasMore"`
+	FullSnapshot  bool                   `json:"fullSnapshot"`
+	Profile       *UserResponse          `json:"profile"`
+	Drinks        []DrinkResponse        `json:"drinks"`
+	HydrationLogs []HydrationLogResponse `json:"hydrationLogs"`
+	DailyGoals    []DailyGoalResponse    `json:"dailyGoals"`
+	Tombstones    []SyncTombstone        `json:"tombstones"`
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// TimezoneRekeyRequest corrects the timezone of entries recorded between From and To: logs,
+// including trashed ones, exercise sessions, sweat tests, weight measurements and safety
+// warnings. When FromTimezone is set only entries recorded in that zone are touched.
+type TimezoneRekeyRequest struct {
+	Timezone     string     `json:"timezone"`
+	From         time.Time  `json:"from"`
+	To           *time.Time `json:"to"`
+	FromTimezone *string    `json:"fromTimezone"`
+}
+
+type TimezoneRekeyChange struct {
+	LogID       uuid.UUID `json:"logId"`
+	ConsumedAt  time.Time `json:"consumedAt"`
+	OldTimezone string    `json:"oldTimezone"`
+	NewTimezone string    `json:"newTimezone"`
+	OldDailyKey string    `json:"oldDailyKey"`
+	NewDailyKey string    `json:"newDailyKey"`
+}
+
+type TimezoneRekeyResponse struct {
+	Applied          bool                  `json:"applied"`
+	AffectedLogs     int                   `json:"affectedLogs"`
+	AffectedSessions int                   `json:"affectedSessions"`
+	ChangedDays      []string              `json:"changedDays"`
+	Changes          []TimezoneRekeyChange `json:"changes"`
+}
+
+type TimezoneChangeResponse struct {
+	ID               uuid.UUID `json:"id"`
+	Timezone         string    `json:"timezone"`
+	PreviousTimezone string    `json:"previousTimezone"`
+	EffectiveAt      time.Time `json:"effectiveAt"`
+	Source           string    `json:"source"`
+}
+
+func NewTimezoneChangeResponse(change models.TimezoneChange) TimezoneChangeResponse {
+	return TimezoneChangeResponse{
+		ID:               change.ID,
+		Timezone:         change.Timezone,
+		PreviousTimezone: change.PreviousTimezone,
+		EffectiveAt:      change.EffectiveAt,
+		Source:           change.Source,
+	}
+}
@@ -36,6 +36,7 @@ type CreateUserRequest struct {
 	ProgressWheelStyle        string          `json:"progressWheelStyle"`
 	WeatherAdjustmentsEnabled bool            `json:"weatherAdjustmentsEnabled"`
 	CustomGoalLiters          *float64        `json:"customGoalLiters"`
+	GoalStrategy              string          `json:"goalStrategy"`
 }
 
 type UpdateUserRequest struct {
@@ -52,44 +53,65 @@ type UpdateUserRequest struct {
 	ProgressWheelStyle        *string          `json:"progressWheelStyle"`
 	WeatherAdjustmentsEnabled *bool            `json:"weatherAdjustmentsEnabled"`
 	CustomGoalLiters          *float64         `json:"customGoalLiters"`
+	GoalStrategy              *string          `json:"goalStrategy"`
+	DayStartOffsetMinutes     *int             `json:"dayStartOffsetMinutes"`
+	WakeTimeMinutes           *int             `json:"wakeTimeMinutes"`
+	SleepTimeMinutes          *int             `json:"sleepTimeMinutes"`
+	FluidRestrictionEnabled   *bool            `json:"fluidRestrictionEnabled"`
+	CaffeineLimitMg           *float64         `json:"caffeineLimitMg"`
+	AlcoholLimitUnits         *float64         `json:"alcoholLimitUnits"`
+	CaffeineHalfLifeMinutes   *int             `json:"caffeineHalfLifeMinutes"`
+	CaffeineBedtimeLimitMg    *float64         `json:"caffeineBedtimeLimitMg"`
+	SweatRateGoalsEnabled     *bool            `json:"sweatRateGoalsEnabled"`
 }
 
 type UserResponse struct {
-	ID                        uuid.UUID       `json:"id"`
-	Email                     string          `json:"email"`
-	EmailVerified             bool            `json:"emailVerified"`
-	DisplayName               string          `json:"displayName"`
-	HasPassword               bool            `json:"hasPassword"`
-	IsGoogleUser              bool            `json:"isGoogleUser"`
-	TwoFactorEnabled          bool            `json:"twoFactorEnabled"`
-	WeightKg                  float64         `json:"weight"`
-	WeightUnit                string          `json:"weightUnit"`
-	Age                       int             `json:"age"`
-	Gender                    string          `json:"gender"`
-	ActivityLevel             string          `json:"activityLevel"`
-	Timezone                  string          `json:"timezone"`
-	Location                  LocationPayload `json:"location"`
-	DailyGoalLiters           float64         `json:"dailyGoalLiters"`
-	CustomGoalLiters          *float64        `json:"customGoalLiters"`
-	VolumeUnit                string          `json:"volumeUnit"`
-	TemperatureUnit           string          `json:"temperatureUnit"`
-	ProgressWheelStyle        string          `json:"progressWheelStyle"`
-	WeatherAdjustmentsEnabled bool            `json:"weatherAdjustmentsEnabled"`
-	LastLoginAt               *time.Time      `json:"lastLoginAt"`
-	CreatedAt                 time.Time       `json:"createdAt"`
-	UpdatedAt                 time.Time       `json:"updatedAt"`
-	PrivacyAcceptedVersion    *string         `json:"privacyAcceptedVersion"`
-	PrivacyAcceptedAt         *time.Time      `json:"privacyAcceptedAt"`
-	TermsAcceptedVersion      *string         `json:"termsAcceptedVersion"`
-	TermsAcceptedAt           *time.Time      `json:"termsAcceptedAt"`
-	PrivacyCurrentVersion     string          `json:"privacyCurrentVersion"`
-	TermsCurrentVersion       string          `json:"termsCurrentVersion"`
-	RequiresPrivacyAcceptance bool            `json:"requiresPrivacyAcceptance"`
-	RequiresTermsAcceptance   bool            `json:"requiresTermsAcceptance"`
-	PoliciesAcceptedVersion   *string         `json:"policiesAcceptedVersion"`  // Backward compatibility
-	PoliciesAcceptedAt        *time.Time      `json:"policiesAcceptedAt"`       // Backward compatibility
-	PoliciesCurrentVersion    string          `json:"policiesCurrentVersion"`   // Backward compatibility
-	RequiresPolicyAcceptance  bool            `json:"requiresPolicyAcceptance"` // Backward compatibility
+	ID                        uuid.UUID              `json:"id"`
+	Email                     string                 `json:"email"`
+	EmailVerified             bool                   `json:"emailVerified"`
+	DisplayName               string                 `json:"displayName"`
+	HasPassword               bool                   `json:"hasPassword"`
+	IsGoogleUser              bool                   `json:"isGoogleUser"`
+	TwoFactorEnabled          bool                   `json:"twoFactorEnabled"`
+	WeightKg                  float64                `json:"weight"`
+	WeightUnit                string                 `json:"weightUnit"`
+	Age                       int                    `json:"age"`
+	Gender                    string                 `json:"gender"`
+	ActivityLevel             string                 `json:"activityLevel"`
+	Timezone                  string                 `json:"timezone"`
+	Location                  LocationPayload        `json:"location"`
+	DailyGoalLiters           float64                `json:"dailyGoalLiters"`
+	CustomGoalLiters          *float64               `json:"customGoalLiters"`
+	GoalStrategy              string                 `json:"goalStrategy"`
+	GoalStrategyInputs        map[string]interface{} `json:"goalStrategyInputs"`
+	VolumeUnit                string                 `json:"volumeUnit"`
+	TemperatureUnit           string                 `json:"temperatureUnit"`
+	ProgressWheelStyle        string                 `json:"progressWheelStyle"`
+	WeatherAdjustmentsEnabled bool                   `json:"weatherAdjustmentsEnabled"`
+	DayStartOffsetMinutes     int                    `json:"dayStartOffsetMinutes"`
+	WakeTimeMinutes           int                    `json:"wakeTimeMinutes"`
+	SleepTimeMinutes          int                    `json:"sleepTimeMinutes"`
+	FluidRestrictionEnabled   bool                   `json:"fluidRestrictionEnabled"`
+	CaffeineLimitMg           *float64               `json:"caffeineLimitMg"`
+	AlcoholLimitUnits         *float64               `json:"alcoholLimitUnits"`
+	CaffeineHalfLifeMinutes   int                    `json:"caffeineHalfLifeMinutes"`
+	CaffeineBedtimeLimitMg    float64                `json:"caffeineBedtimeLimitMg"`
+	SweatRateGoalsEnabled     bool                   `json:"sweatRateGoalsEnabled"`
+	LastLoginAt               *time.Time             `json:"lastLoginAt"`
+	CreatedAt                 time.Time              `json:"createdAt"`
+	UpdatedAt                 time.Time              `json:"updatedAt"`
+	PrivacyAcceptedVersion    *string                `json:"privacyAcceptedVersion"`
+	PrivacyAcceptedAt         *time.Time             `json:"privacyAcceptedAt"`
+	TermsAcceptedVersion      *string                `json:"termsAcceptedVersion"`
+	TermsAcceptedAt           *time.Time             `json:"termsAcceptedAt"`
+	PrivacyCurrentVersion     string                 `json:"privacyCurrentVersion"`
+	TermsCurrentVersion       string                 `json:"termsCurrentVersion"`
+	RequiresPrivacyAcceptance bool                   `json:"requiresPrivacyAcceptance"`
+	RequiresTermsAcceptance   bool                   `json:"requiresTermsAcceptance"`
+	PoliciesAcceptedVersion   *string                `json:"policiesAcceptedVersion"`  // Backward compatibility
+	PoliciesAcceptedAt        *time.Time             `json:"policiesAcceptedAt"`       // Backward compatibility
+	PoliciesCurrentVersion    string                 `json:"policiesCurrentVersion"`   // Backward compatibility
+	RequiresPolicyAcceptance  bool                   `json:"requiresPolicyAcceptance"` // Backward compatibility
 }
 
 type UserSummaryResponse struct {
@@ -129,10 +151,21 @@ func NewUserResponse(user models.User, currentPrivacyVersion, currentTermsVersio
 		},
 		DailyGoalLiters:           user.DailyGoalLiters,
 		CustomGoalLiters:          user.CustomGoalLiters,
+		GoalStrategy:              user.GoalStrategy,
+		GoalStrategyInputs:        user.GoalStrategyInputs,
 		VolumeUnit:                user.VolumeUnit,
 		TemperatureUnit:           user.TemperatureUnit,
 		ProgressWheelStyle:        user.ProgressWheelStyle,
 		WeatherAdjustmentsEnabled: user.WeatherAdjustmentsEnabled,
+		DayStartOffsetMinutes:     user.DayStartOffsetMinutes,
+		WakeTimeMinutes:           user.WakeTimeMinutes,
+		SleepTimeMinutes:          user.SleepTimeMinutes,
+		FluidRestrictionEnabled:   user.FluidRestrictionEnabled,
+		CaffeineLimitMg:           user.CaffeineLimitMg,
+		AlcoholLimitUnits:         user.AlcoholLimitUnits,
+		CaffeineHalfLifeMinutes:   user.CaffeineHalfLifeMinutes,
+		CaffeineBedtimeLimitMg:    user.CaffeineBedtimeLimitMg,
+		SweatRateGoalsEnabled:     user.SweatRateGoalsEnabled,
 		LastLoginAt:               user.LastLoginAt,
 		CreatedAt:                 user.CreatedAt,
 		UpdatedAt:                 user.UpdatedAt,
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/AD-Archer/archer-aqua/backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+type CreateWeightMeasurementRequest struct {
+	Weight     WeightPayload `json:"weight"`
+	MeasuredAt *time.Time    `json:"measuredAt"`
+	Timezone   string        `json:"timezone"`
+	Notes      *string       `json:"notes"`
+}
+
+type WeightMeasurementResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Weight     float64   `json:"weight"`
+	WeightUnit string    `json:"weightUnit"`
+	WeightKg   float64   `json:"weightKg"`
+	MeasuredAt time.Time `json:"measuredAt"`
+	Timezone   string    `json:"timezone"`
+	DailyKey   string    `json:"dailyKey"`
+	Source     string    `json:"source"`
+	Notes      *string   `json:"notes"`
+}
+
+func NewWeightMeasurementResponse(measurement models.WeightMeasurement) WeightMeasurementResponse {
+	weight := measurement.WeightKg
+	unit := "kg"
+	if measurement.Unit != "" {
+		if converted, err := utils.ConvertWeightFromKg(measurement.WeightKg, measurement.Unit); err == nil {
+			weight = converted
+			unit = measurement.Unit
+		}
+	}
+
+	return WeightMeasurementResponse{
+		ID:         measurement.ID,
+		Weight:     weight,
+		WeightUnit: unit,
+		WeightKg:   measurement.WeightKg,
+		MeasuredAt: measurement.MeasuredAt,
+		Timezone:   measurement.Timezone,
+		DailyKey:   measurement.DailyKey,
+		Source:     measurement.Source,
+		Notes:      measurement.Notes,
+	}
+}
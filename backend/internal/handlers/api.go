@@ -13,10 +13,18 @@ type API struct {
 	dailyGoals *services.DailyGoalService
 	auth       *services.AuthService
 	weather    *services.WeatherService
+	sync       *services.SyncService
+	streaks    *services.StreakService
+	timezones  *services.TimezoneService
+	exercise   *services.ExerciseService
+	caffeine   *services.CaffeineService
+	containers *services.ContainerService
+	weights    *services.WeightService
+	sweatTests *services.SweatTestService
 	logger     *slog.Logger
 }
 
-func NewAPI(userService *services.UserService, drinkService *services.DrinkService, hydrationService *services.HydrationService, dailyGoalService *services.DailyGoalService, authService *services.AuthService, weatherService *services.WeatherService, logger *slog.Logger) *API {
+func NewAPI(userService *services.UserService, drinkService *services.DrinkService, hydrationService *services.HydrationService, dailyGoalService *services.DailyGoalService, authService *services.AuthService, weatherService *services.WeatherService, syncService *services.SyncService, streakService *services.StreakService, timezoneService *services.TimezoneService, exerciseService *services.ExerciseService, caffeineService *services.CaffeineService, containerService *services.ContainerService, weightService *services.WeightService, sweatTestService *services.SweatTestService, logger *slog.Logger) *API {
 	return &API{
 		users:      userService,
 		drinks:     drinkService,
@@ -24,6 +32,14 @@ func NewAPI(userService *services.UserService, drinkService *services.DrinkServi
 		dailyGoals: dailyGoalService,
 		auth:       authService,
 		weather:    weatherService,
+		sync:       syncService,
+		streaks:    streakService,
+		timezones:  timezoneService,
+		exercise:   exerciseService,
+		caffeine:   caffeineService,
+		containers: containerService,
+		weights:    weightService,
+		sweatTests: sweatTestService,
 		logger:     logger,
 	}
 }
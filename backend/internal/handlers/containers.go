@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/services"
+)
+
+func (api *API) ListContainers(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	includeArchived := r.URL.Query().Get("archived") == "true"
+	containers, err := api.containers.ListContainers(r.Context(), userID, includeArchived)
+	if err != nil {
+		logError(api.logger, "list containers", err)
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	responses := make([]dto.ContainerResponse, 0, len(containers))
+	for _, container := range containers {
+		responses = append(responses, dto.NewContainerResponse(container))
+	}
+
+	respondJSON(w, http.StatusOK, responses)
+}
+
+func (api *API) CreateContainer(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	var request dto.CreateContainerRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+
+	container, err := api.containers.CreateContainer(r.Context(), userID, request)
+	if err != nil {
+		logError(api.logger, "create container", err)
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.NewContainerResponse(*container))
+}
+
+func (api *API) UpdateContainer(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	containerID, err := parseUUIDParam(r, "containerID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid container id")
+		return
+	}
+
+	var request dto.UpdateContainerRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+
+	container, err := api.containers.UpdateContainer(r.Context(), userID, containerID, request)
+	if err != nil {
+		logError(api.logger, "update container", err)
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrContainerNotFound) {
+			status = http.StatusNotFound
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.NewContainerResponse(*container))
+}
+
+func (api *API) DeleteContainer(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	containerID, err := parseUUIDParam(r, "containerID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid container id")
+		return
+	}
+
+	if err := api.containers.DeleteContainer(r.Context(), userID, containerID); err != nil {
+		logError(api.logger, "delete container", err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrContainerNotFound) {
+			status = http.StatusNotFound
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *API) FillContainer(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	containerID, err := parseUUIDParam(r, "containerID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid container id")
+		return
+	}
+
+	// An empty body fills the container to capacity.
+	var request dto.FillContainerRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid payload")
+			return
+		}
+	}
+
+	container, err := api.containers.FillContainer(r.Context(), userID, containerID, request)
+	if err != nil {
+		logError(api.logger, "fill container", err)
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrContainerNotFound) {
+			status = http.StatusNotFound
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.NewContainerResponse(*container))
+}
+
+func (api *API) SipFromContainer(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	containerID, err := parseUUIDParam(r, "containerID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid container id")
+		return
+	}
+
+	var request dto.ContainerSipRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+
+	entry, container, err := api.containers.Sip(r.Context(), userID, containerID, request)
+	if err != nil {
+		logError(api.logger, "sip from container", err)
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrContainerNotFound) {
+			status = http.StatusNotFound
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ContainerSipResponse{
+		Log:       dto.NewHydrationLogResponse(*entry),
+		Container: dto.NewContainerResponse(*container),
+	})
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/services"
+)
+
+func (api *API) ListExerciseSessions(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	query := r.URL.Query()
+	sessions, err := api.exercise.ListSessions(r.Context(), userID, query.Get("from"), query.Get("to"))
+	if err != nil {
+		logError(api.logger, "list exercise sessions", err)
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	responses := make([]dto.ExerciseSessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, dto.NewExerciseSessionResponse(session))
+	}
+
+	respondJSON(w, http.StatusOK, responses)
+}
+
+func (api *API) CreateExerciseSession(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	var request dto.CreateExerciseSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+
+	session, err := api.exercise.CreateSession(r.Context(), userID, request)
+	if err != nil {
+		logError(api.logger, "create exercise session", err)
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.NewExerciseSessionResponse(*session))
+}
+
+func (api *API) UpdateExerciseSession(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	sessionID, err := parseUUIDParam(r, "sessionID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid exercise session id")
+		return
+	}
+
+	var request dto.UpdateExerciseSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+
+	session, err := api.exercise.UpdateSession(r.Context(), userID, sessionID, request)
+	if err != nil {
+		logError(api.logger, "update exercise session", err)
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrExerciseSessionNotFound) {
+			status = http.StatusNotFound
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.NewExerciseSessionResponse(*session))
+}
+
+func (api *API) DeleteExerciseSession(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	sessionID, err := parseUUIDParam(r, "sessionID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid exercise session id")
+		return
+	}
+
+	if err := api.exercise.DeleteSession(r.Context(), userID, sessionID); err != nil {
+		logError(api.logger, "delete exercise session", err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrExerciseSessionNotFound) {
+			status = http.StatusNotFound
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/services"
+)
+
+func (api *API) ListGoalRules(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	rules, err := api.dailyGoals.ListGoalRules(r.Context(), userID)
+	if err != nil {
+		logError(api.logger, "list goal rules", err)
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	responses := make([]dto.GoalRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		responses = append(responses, dto.NewGoalRuleResponse(rule))
+	}
+
+	respondJSON(w, http.StatusOK, responses)
+}
+
+func (api *API) CreateGoalRule(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	var request dto.GoalRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+
+	rule, err := api.dailyGoals.CreateGoalRule(r.Context(), userID, request)
+	if err != nil {
+		logError(api.logger, "create goal rule", err)
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.NewGoalRuleResponse(*rule))
+}
+
+func (api *API) UpdateGoalRule(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	ruleID, err := parseUUIDParam(r, "ruleID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid goal rule id")
+		return
+	}
+
+	var request dto.GoalRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+
+	rule, err := api.dailyGoals.UpdateGoalRule(r.Context(), userID, ruleID, request)
+	if err != nil {
+		logError(api.logger, "update goal rule", err)
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrGoalRuleNotFound) {
+			status = http.StatusNotFound
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.NewGoalRuleResponse(*rule))
+}
+
+func (api *API) DeleteGoalRule(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	ruleID, err := parseUUIDParam(r, "ruleID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid goal rule id")
+		return
+	}
+
+	if err := api.dailyGoals.DeleteGoalRule(r.Context(), userID, ruleID); err != nil {
+		logError(api.logger, "delete goal rule", err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrGoalRuleNotFound) {
+			status = http.StatusNotFound
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
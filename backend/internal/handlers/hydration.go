@@ -3,12 +3,14 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
 	"github.com/AD-Archer/archer-aqua/backend/internal/services"
+	"github.com/google/uuid"
 )
 
 func (api *API) LogHydration(w http.ResponseWriter, r *http.Request) {
@@ -38,6 +40,209 @@ func (api *API) LogHydration(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusCreated, dto.NewHydrationLogResponse(*entry))
 }
 
+func (api *API) LogHydrationBatch(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	var request dto.LogHydrationBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+
+	if len(request.Logs) == 0 {
+		respondError(w, http.StatusBadRequest, "logs must not be empty")
+		return
+	}
+
+	results, err := api.hydration.LogHydrationBatch(r.Context(), userID, request.Logs)
+	if err != nil {
+		logError(api.logger, "log hydration batch", err)
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.LogHydrationBatchResponse{Results: results})
+}
+
+func (api *API) ListHydrationLogs(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	params := r.URL.Query()
+	query := dto.HydrationLogQuery{
+		From:   params.Get("from"),
+		To:     params.Get("to"),
+		Source: params.Get("source"),
+		Label:  params.Get("label"),
+		Cursor: params.Get("cursor"),
+	}
+
+	for _, dateStr := range []string{query.From, query.To} {
+		if dateStr == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, dateStr); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid date format (expected YYYY-MM-DD)")
+			return
+		}
+	}
+
+	if drinkIDStr := params.Get("drinkId"); drinkIDStr != "" {
+		drinkID, err := uuid.Parse(drinkIDStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid drink id")
+			return
+		}
+		query.DrinkID = &drinkID
+	}
+
+	if minStr := params.Get("minVolumeMl"); minStr != "" {
+		minVolume, err := strconv.ParseFloat(minStr, 64)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid minVolumeMl")
+			return
+		}
+		query.MinVolumeMl = &minVolume
+	}
+
+	if maxStr := params.Get("maxVolumeMl"); maxStr != "" {
+		maxVolume, err := strconv.ParseFloat(maxStr, 64)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid maxVolumeMl")
+			return
+		}
+		query.MaxVolumeMl = &maxVolume
+	}
+
+	if limitStr := params.Get("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
+			query.Limit = parsed
+		}
+	}
+
+	page, err := api.hydration.ListHydrationLogs(r.Context(), userID, query)
+	if err != nil {
+		logError(api.logger, "list hydration logs", err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidCursor) {
+			status = http.StatusBadRequest
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, page)
+}
+
+func (api *API) UpdateHydrationLog(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	logID, err := parseUUIDParam(r, "logID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid hydration log id")
+		return
+	}
+
+	var request dto.UpdateHydrationLogRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+
+	entry, err := api.hydration.UpdateHydrationLog(r.Context(), userID, logID, request)
+	if err != nil {
+		logError(api.logger, "update hydration log", err)
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrHydrationLogNotFound) {
+			status = http.StatusNotFound
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.NewHydrationLogResponse(*entry))
+}
+
+func (api *API) ListDeletedHydrationLogs(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	logs, err := api.hydration.ListDeletedHydrationLogs(r.Context(), userID)
+	if err != nil {
+		logError(api.logger, "list deleted hydration logs", err)
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	responses := make([]dto.HydrationLogResponse, 0, len(logs))
+	for _, logEntry := range logs {
+		responses = append(responses, dto.NewHydrationLogResponse(logEntry))
+	}
+
+	respondJSON(w, http.StatusOK, responses)
+}
+
+func (api *API) RestoreHydrationLog(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	logID, err := parseUUIDParam(r, "logID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid hydration log id")
+		return
+	}
+
+	entry, err := api.hydration.RestoreHydrationLog(r.Context(), userID, logID)
+	if err != nil {
+		logError(api.logger, "restore hydration log", err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrHydrationLogNotFound) {
+			status = http.StatusNotFound
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.NewHydrationLogResponse(*entry))
+}
+
 func (api *API) DailySummary(w http.ResponseWriter, r *http.Request) {
 	userID, err := parseUUIDParam(r, "userID")
 	if err != nil {
@@ -84,15 +289,27 @@ func (api *API) HydrationStats(w http.ResponseWriter, r *http.Request) {
 	}
 
 	tz := r.URL.Query().Get("timezone")
+	interval := r.URL.Query().Get("interval")
 	daysStr := r.URL.Query().Get("days")
-	days := 7
+	days := 0
 	if daysStr != "" {
 		if parsed, err := strconv.Atoi(daysStr); err == nil && parsed > 0 {
 			days = parsed
 		}
+		if days > services.MaxStatsDays {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("days must be at most %d", services.MaxStatsDays))
+			return
+		}
+	}
+
+	switch interval {
+	case "", services.StatsIntervalDay, services.StatsIntervalWeek, services.StatsIntervalMonth, services.StatsIntervalYear:
+	default:
+		respondError(w, http.StatusBadRequest, "invalid interval (expected day, week, month, or year)")
+		return
 	}
 
-	stats, err := api.hydration.WeeklyStats(r.Context(), userID, tz, days)
+	stats, err := api.hydration.WeeklyStats(r.Context(), userID, tz, days, interval)
 	if err != nil {
 		logError(api.logger, "hydration stats", err)
 		respondError(w, http.StatusInternalServerError, err.Error())
@@ -168,14 +385,7 @@ func (api *API) SetDailyGoal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := dto.DailyGoalResponse{
-		ID:     dailyGoal.ID.String(),
-		UserID: dailyGoal.UserID.String(),
-		Date:   dailyGoal.Date,
-		GoalMl: dailyGoal.GoalMl,
-	}
-
-	respondJSON(w, http.StatusOK, response)
+	respondJSON(w, http.StatusOK, dto.NewDailyGoalResponse(*dailyGoal))
 }
 
 func (api *API) GetDailyGoal(w http.ResponseWriter, r *http.Request) {
@@ -238,8 +448,11 @@ func (api *API) DeleteDailyGoal(w http.ResponseWriter, r *http.Request) {
 	if err := api.dailyGoals.DeleteDailyGoal(r.Context(), userID, dateStr); err != nil {
 		logError(api.logger, "delete daily goal", err)
 		status := http.StatusInternalServerError
-		if errors.Is(err, services.ErrDailyGoalNotFound) {
+		switch {
+		case errors.Is(err, services.ErrDailyGoalNotFound):
 			status = http.StatusNotFound
+		case errors.Is(err, services.ErrPastDailyGoal):
+			status = http.StatusBadRequest
 		}
 		respondError(w, status, err.Error())
 		return
@@ -247,3 +460,70 @@ func (api *API) DeleteDailyGoal(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+func (api *API) ListSafetyWarnings(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	query := r.URL.Query()
+	warnings, err := api.hydration.ListSafetyWarnings(r.Context(), userID, query.Get("from"), query.Get("to"))
+	if err != nil {
+		logError(api.logger, "list safety warnings", err)
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	responses := make([]dto.SafetyWarningResponse, 0, len(warnings))
+	for _, warning := range warnings {
+		responses = append(responses, dto.NewSafetyWarningResponse(warning))
+	}
+
+	respondJSON(w, http.StatusOK, responses)
+}
+
+func (api *API) CaffeineCurve(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	dateStr := r.URL.Query().Get("date")
+	if dateStr == "" {
+		respondError(w, http.StatusBadRequest, "date query parameter is required")
+		return
+	}
+	if _, err := time.Parse(time.DateOnly, dateStr); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid date format (expected YYYY-MM-DD)")
+		return
+	}
+
+	step := 0
+	if stepStr := r.URL.Query().Get("step"); stepStr != "" {
+		step, err = strconv.Atoi(stepStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid step")
+			return
+		}
+	}
+
+	curve, err := api.caffeine.Curve(r.Context(), userID, dateStr, step)
+	if err != nil {
+		logError(api.logger, "caffeine curve", err)
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, curve)
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/services"
+)
+
+func (api *API) GetStreak(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	streak, err := api.streaks.Compute(r.Context(), userID)
+	if err != nil {
+		logError(api.logger, "compute streak", err)
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, streak)
+}
+
+func (api *API) ListExcusedDays(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	days, err := api.streaks.ListExcusedDays(r.Context(), userID)
+	if err != nil {
+		logError(api.logger, "list excused days", err)
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	responses := make([]dto.ExcusedDayResponse, 0, len(days))
+	for _, day := range days {
+		responses = append(responses, dto.NewExcusedDayResponse(day))
+	}
+
+	respondJSON(w, http.StatusOK, responses)
+}
+
+func (api *API) SetExcusedDay(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	var request dto.ExcusedDayRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+
+	// Validate date format
+	if _, err := time.Parse(time.DateOnly, request.Date); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid date format (expected YYYY-MM-DD)")
+		return
+	}
+
+	day, err := api.streaks.SetExcusedDay(r.Context(), userID, request)
+	if err != nil {
+		logError(api.logger, "set excused day", err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidExcuse) {
+			status = http.StatusBadRequest
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.NewExcusedDayResponse(*day))
+}
+
+func (api *API) DeleteExcusedDay(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	dateStr := r.URL.Query().Get("date")
+	if dateStr == "" {
+		respondError(w, http.StatusBadRequest, "date query parameter is required")
+		return
+	}
+
+	// Validate date format
+	if _, err := time.Parse(time.DateOnly, dateStr); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid date format (expected YYYY-MM-DD)")
+		return
+	}
+
+	if err := api.streaks.DeleteExcusedDay(r.Context(), userID, dateStr); err != nil {
+		logError(api.logger, "delete excused day", err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrExcusedDayNotFound) {
+			status = http.StatusNotFound
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
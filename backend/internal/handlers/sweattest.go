@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/services"
+)
+
+func (api *API) ListSweatTests(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	query := r.URL.Query()
+	tests, err := api.sweatTests.ListSweatTests(r.Context(), userID, query.Get("from"), query.Get("to"))
+	if err != nil {
+		logError(api.logger, "list sweat tests", err)
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	responses := make([]dto.SweatTestResponse, 0, len(tests))
+	for _, test := range tests {
+		responses = append(responses, dto.NewSweatTestResponse(test, services.SweatReplacementMlPerHour(test)))
+	}
+
+	respondJSON(w, http.StatusOK, responses)
+}
+
+func (api *API) CreateSweatTest(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	var request dto.CreateSweatTestRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+
+	test, err := api.sweatTests.CreateSweatTest(r.Context(), userID, request)
+	if err != nil {
+		logError(api.logger, "create sweat test", err)
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.NewSweatTestResponse(*test, services.SweatReplacementMlPerHour(*test)))
+}
+
+func (api *API) DeleteSweatTest(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	testID, err := parseUUIDParam(r, "testID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid sweat test id")
+		return
+	}
+
+	if err := api.sweatTests.DeleteSweatTest(r.Context(), userID, testID); err != nil {
+		logError(api.logger, "delete sweat test", err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrSweatTestNotFound) {
+			status = http.StatusNotFound
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/services"
+)
+
+func (api *API) SyncChanges(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	cursor := r.URL.Query().Get("cursor")
+
+	changes, err := api.sync.Changes(r.Context(), userID, cursor, api.auth.CurrentPrivacyVersion(), api.auth.CurrentTermsVersion())
+	if err != nil {
+		logError(api.logger, "sync changes", err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidSyncCursor) {
+			status = http.StatusBadRequest
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, changes)
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+)
+
+func (api *API) TimezoneHistory(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	changes, err := api.timezones.History(r.Context(), userID)
+	if err != nil {
+		logError(api.logger, "list timezone history", err)
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	responses := make([]dto.TimezoneChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		responses = append(responses, dto.NewTimezoneChangeResponse(change))
+	}
+
+	respondJSON(w, http.StatusOK, responses)
+}
+
+func (api *API) PreviewTimezoneRekey(w http.ResponseWriter, r *http.Request) {
+	api.timezoneRekey(w, r, false)
+}
+
+func (api *API) ApplyTimezoneRekey(w http.ResponseWriter, r *http.Request) {
+	api.timezoneRekey(w, r, true)
+}
+
+func (api *API) timezoneRekey(w http.ResponseWriter, r *http.Request, apply bool) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	var request dto.TimezoneRekeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+
+	result, err := api.timezones.Rekey(r.Context(), userID, request, apply)
+	if err != nil {
+		logError(api.logger, "rekey timezone", err)
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
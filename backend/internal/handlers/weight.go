@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/services"
+)
+
+func (api *API) ListWeightMeasurements(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	query := r.URL.Query()
+	measurements, err := api.weights.ListMeasurements(r.Context(), userID, query.Get("from"), query.Get("to"))
+	if err != nil {
+		logError(api.logger, "list weight measurements", err)
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	responses := make([]dto.WeightMeasurementResponse, 0, len(measurements))
+	for _, measurement := range measurements {
+		responses = append(responses, dto.NewWeightMeasurementResponse(measurement))
+	}
+
+	respondJSON(w, http.StatusOK, responses)
+}
+
+func (api *API) CreateWeightMeasurement(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	var request dto.CreateWeightMeasurementRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+
+	measurement, err := api.weights.AddMeasurement(r.Context(), userID, request)
+	if err != nil {
+		logError(api.logger, "create weight measurement", err)
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.NewWeightMeasurementResponse(*measurement))
+}
+
+func (api *API) DeleteWeightMeasurement(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUUIDParam(r, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if !api.authorizeUserRequest(w, r, userID) {
+		return
+	}
+
+	measurementID, err := parseUUIDParam(r, "measurementID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid measurement id")
+		return
+	}
+
+	if err := api.weights.DeleteMeasurement(r.Context(), userID, measurementID); err != nil {
+		logError(api.logger, "delete weight measurement", err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrWeightMeasurementNotFound) {
+			status = http.StatusNotFound
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Container is a named bottle or cup the user drinks from repeatedly.
+// CurrentFillMl tracks what is left since the last fill; every log against the container
+// drains it. DefaultDrinkID is used for sips that don't name a drink.
+// ArchivedAt hides a container while keeping the references of past logs intact.
+type Container struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ArchivedAt     *time.Time
+	UserID         uuid.UUID `gorm:"type:uuid;index"`
+	Name           string    `gorm:"size:128"`
+	CapacityMl     float64
+	ColorHex       *string    `gorm:"size:16"`
+	DefaultDrinkID *uuid.UUID `gorm:"type:uuid"`
+	CurrentFillMl  float64    `gorm:"default:0"`
+	FilledAt       *time.Time
+	User           User `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate ensures UUIDs are set.
+func (c *Container) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
@@ -4,19 +4,40 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
+// Daily goal sources.
+const (
+	DailyGoalSourceManual   = "manual"   // set explicitly by the user
+	DailyGoalSourceSnapshot = "snapshot" // frozen from the profile goal when the day saw activity
+)
+
+// GoalAdjustment is one line of a day's goal breakdown, e.g. +400 ml for heat.
+type GoalAdjustment struct {
+	Source   string                 `json:"source"` // adjuster that produced it, e.g. "weather"
+	Reason   string                 `json:"reason"` // e.g. "heat", "dry_air"
+	AmountMl float64                `json:"amountMl"`
+	Inputs   map[string]interface{} `json:"inputs,omitempty"`
+}
+
 // DailyGoal tracks the hydration goal for a specific day.
 // This allows users to set different goals for different days,
 // and historical data will reflect the goal that was set for that day.
+// Snapshot rows are written automatically for every day with activity so that
+// later profile edits don't change whether past days were completed.
 type DailyGoal struct {
-	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	UserID    uuid.UUID `gorm:"type:uuid;index"`
-	Date      string    `gorm:"size:16;index"` // YYYY-MM-DD format
-	GoalMl    float64   // Goal in milliliters
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	UserID         uuid.UUID                           `gorm:"type:uuid;index"`
+	Date           string                              `gorm:"size:16;index"` // YYYY-MM-DD format
+	GoalMl         float64                             // Goal in milliliters, BaseGoalMl plus Adjustments
+	BaseGoalMl     float64                             // manual or profile goal before adjustments
+	BaseComponents datatypes.JSONSlice[GoalAdjustment] `gorm:"type:jsonb"` // lines summing to BaseGoalMl
+	Adjustments    datatypes.JSONSlice[GoalAdjustment] `gorm:"type:jsonb"`
+	Source         string                              `gorm:"size:32;default:'manual'"`
 }
 
 // BeforeCreate ensures UUIDs are set.
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DailyRollup caches per-day totals for a user so summaries and stats avoid scanning raw logs.
+// Rows are recomputed inside the same transaction as every log write and can be rebuilt from
+// hydration_logs at any time. GoalMl and Status capture the goal in effect when the row was
+// last refreshed.
+type DailyRollup struct {
+	ID                uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	UserID            uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_daily_rollups_user_day,priority:1"`
+	DailyKey          string    `gorm:"size:16;uniqueIndex:idx_daily_rollups_user_day,priority:2"`
+	TotalVolumeMl     float64
+	TotalEffectiveMl  float64
+	TotalFoodMl       float64 // part of TotalEffectiveMl that came from food
+	TotalCaffeineMg   float64
+	TotalSugarG       float64
+	TotalAlcoholUnits float64
+	LogCount          int64
+	GoalMl            float64
+	Status            string `gorm:"size:32"`
+	User              User   `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate ensures UUIDs are set.
+func (d *DailyRollup) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
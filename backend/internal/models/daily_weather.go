@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DailyWeather keeps the hottest weather reading of each of a user's days. WeatherData is a
+// short-lived cache overwritten on every refresh; this row only ever moves to a hotter reading,
+// so the weather goal adjuster gets the same inputs whenever the day's goal is recomputed.
+type DailyWeather struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	UserID      uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_daily_weathers_user_day,priority:1"`
+	DailyKey    string    `gorm:"size:16;uniqueIndex:idx_daily_weathers_user_day,priority:2"`
+	Temperature float64
+	FeelsLike   float64
+	Humidity    float64
+	FetchedAt   time.Time
+	User        User `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate ensures UUIDs are set.
+func (d *DailyWeather) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
@@ -15,6 +15,10 @@ import (
 // Color may be used client-side for progress wheel display.
 // Source indicates whether the drink was user-custom, default, or synced from integrations.
 // ArchivedAt allows soft deletion while keeping historic log references intact.
+// WaterContentRatio is the share of a food's mass that is water (0.92 for watermelon); food
+// logged by mass counts that much water towards hydration.
+// Nutrient fields are per 100 ml, or per 100 g for food logged by mass, and are copied onto each
+// log as absolute amounts at log time.
 //
 // Note: keep enum values aligned with frontend constants when available.
 // Source values: "default", "custom", "integration".
@@ -24,19 +28,23 @@ import (
 //
 // Unique constraint ensures a user can't create duplicate drink names differing only by case.
 type Drink struct {
-	ID                  uuid.UUID `gorm:"type:uuid;primaryKey"`
-	CreatedAt           time.Time
-	UpdatedAt           time.Time
-	ArchivedAt          *time.Time
-	UserID              *uuid.UUID `gorm:"type:uuid;index:idx_drinks_user_name,priority:1"`
-	Name                string     `gorm:"size:128;index:idx_drinks_user_name,priority:2"`
-	Type                string     `gorm:"size:32;default:'beverage'"`
-	HydrationMultiplier float64    `gorm:"default:1.0"`
-	DefaultVolumeMl     *float64
-	ColorHex            *string           `gorm:"size:16"`
-	Source              string            `gorm:"size:32;default:'custom'"`
-	Metadata            datatypes.JSONMap `gorm:"type:jsonb"`
-	HydrationLogs       []HydrationLog
+	ID                   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	ArchivedAt           *time.Time
+	UserID               *uuid.UUID `gorm:"type:uuid;index:idx_drinks_user_name,priority:1"`
+	Name                 string     `gorm:"size:128;index:idx_drinks_user_name,priority:2"`
+	Type                 string     `gorm:"size:32;default:'beverage'"`
+	HydrationMultiplier  float64    `gorm:"default:1.0"`
+	DefaultVolumeMl      *float64
+	ColorHex             *string           `gorm:"size:16"`
+	Source               string            `gorm:"size:32;default:'custom'"`
+	WaterContentRatio    float64           `gorm:"default:0"`
+	CaffeineMgPer100Ml   float64           `gorm:"default:0"`
+	SugarGPer100Ml       float64           `gorm:"default:0"`
+	AlcoholUnitsPer100Ml float64           `gorm:"default:0"`
+	Metadata             datatypes.JSONMap `gorm:"type:jsonb"`
+	HydrationLogs        []HydrationLog
 }
 
 // BeforeCreate ensures UUIDs are set.
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ExcusedDay marks a date the user flagged as excused (sick, travel, ...).
+// Excused days neither extend nor break a hydration streak.
+// Reason values: "sick", "travel", "other".
+type ExcusedDay struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	UserID    uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_excused_days_user_date,priority:1"`
+	Date      string    `gorm:"size:16;uniqueIndex:idx_excused_days_user_date,priority:2"` // YYYY-MM-DD format
+	Reason    string    `gorm:"size:32;default:'other'"`
+	Note      *string
+	User      User `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate ensures UUIDs are set.
+func (e *ExcusedDay) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
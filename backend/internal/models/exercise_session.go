@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ExerciseSession records a workout that raises the hydration goal of the day it started on.
+// Intensity values: "low", "moderate", "high".
+type ExerciseSession struct {
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	UserID          uuid.UUID `gorm:"type:uuid;index:idx_exercise_sessions_user_day,priority:1"`
+	Type            string    `gorm:"size:64"` // e.g. "running", "cycling"
+	StartedAt       time.Time
+	DurationMinutes int
+	Intensity       string   `gorm:"size:16;default:'moderate'"`
+	SweatLossMl     *float64 // measured loss, used instead of the intensity estimate when set
+	Timezone        string   `gorm:"size:128"`
+	DailyKey        string   `gorm:"size:16;index:idx_exercise_sessions_user_day,priority:2"`
+	Notes           *string
+	User            User `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate ensures UUIDs are set.
+func (e *ExerciseSession) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
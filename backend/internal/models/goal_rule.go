@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GoalRuleSource marks goal breakdown lines that came from a GoalRule.
+const GoalRuleSource = "goal_rule"
+
+// GoalRule is a recurring goal such as "Mon/Wed/Fri 3 L" or "2 L between two dates".
+// A day matches when its weekday is in Weekdays (a bitmask of 1<<time.Weekday, 0 meaning every
+// day) and it falls inside the optional date range. Single-day DailyGoal overrides win over
+// rules; among matching rules the highest Priority wins, then the most recently updated.
+type GoalRule struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	UserID    uuid.UUID `gorm:"type:uuid;index"`
+	Name      string    `gorm:"size:128"`
+	GoalMl    float64
+	Weekdays  int     `gorm:"default:0"`
+	StartDate *string `gorm:"size:16"` // YYYY-MM-DD, inclusive
+	EndDate   *string `gorm:"size:16"` // YYYY-MM-DD, inclusive
+	Priority  int     `gorm:"default:0"`
+	Enabled   bool
+	User      User `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate ensures UUIDs are set.
+func (g *GoalRule) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	return nil
+}
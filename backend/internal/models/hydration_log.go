@@ -12,23 +12,51 @@ import (
 // Volume and hydration adjustments are stored in milliliters to preserve precision regardless of display units.
 // ConsumedAt stores UTC timestamp; ConsumedAtLocal captures local time with timezone name for display.
 // DailyKey is a YYYY-MM-DD string specific to the user's timezone to simplify daily aggregations.
+// DeletedAt soft-deletes a log into the trash; trashed logs are purged after the configured retention.
+// ContainerID references the container the log was drunk from, if any.
+// SweatTestID references the sweat test the log was drunk during, if any.
+// MassG and WaterContentRatio are set for food logged by mass; VolumeMl is then the water it contains.
+// CaffeineMg, SugarG and AlcoholUnits are the drink's nutrients for this volume, copied when logged
+// so later edits to the drink leave history unchanged.
+// IdempotencyKey is an optional client-generated key that lets offline clients replay logs without duplicates.
+// SafetyWarnings holds the warnings raised when the log was created; it is only populated on that response.
+// CaffeineWarning is likewise only set on the response that created the log.
 type HydrationLog struct {
 	ID                  uuid.UUID `gorm:"type:uuid;primaryKey"`
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
-	UserID              uuid.UUID  `gorm:"type:uuid;index"`
-	DrinkID             *uuid.UUID `gorm:"type:uuid;index"`
-	Label               string     `gorm:"size:128"`
+	DeletedAt           gorm.DeletedAt `gorm:"index"`
+	UserID              uuid.UUID      `gorm:"type:uuid;index;index:idx_hydration_logs_user_day,priority:1;uniqueIndex:idx_hydration_logs_user_idempotency,priority:1"`
+	DrinkID             *uuid.UUID     `gorm:"type:uuid;index"`
+	ContainerID         *uuid.UUID     `gorm:"type:uuid;index"`
+	SweatTestID         *uuid.UUID     `gorm:"type:uuid;index"`
+	Label               string         `gorm:"size:128"`
 	VolumeMl            float64
 	HydrationMultiplier float64 `gorm:"default:1.0"`
 	EffectiveMl         float64
+	MassG               *float64
+	WaterContentRatio   *float64
+	CaffeineMg          float64   `gorm:"default:0"`
+	SugarG              float64   `gorm:"default:0"`
+	AlcoholUnits        float64   `gorm:"default:0"`
 	ConsumedAt          time.Time `gorm:"index"`
 	ConsumedAtLocal     time.Time
 	Timezone            string `gorm:"size:128"`
-	DailyKey            string `gorm:"size:16;index"`
+	DailyKey            string `gorm:"size:16;index;index:idx_hydration_logs_user_day,priority:2"`
 	Source              string `gorm:"size:32;default:'manual'"`
 	Notes               *string
-	Metadata            datatypes.JSONMap `gorm:"type:jsonb"`
+	Metadata            datatypes.JSONMap       `gorm:"type:jsonb"`
+	IdempotencyKey      *string                 `gorm:"size:128;uniqueIndex:idx_hydration_logs_user_idempotency,priority:2"`
+	SafetyWarnings      []SafetyWarning         `gorm:"foreignKey:HydrationLogID;constraint:OnDelete:SET NULL"`
+	CaffeineWarning     *CaffeineBedtimeWarning `gorm:"-"`
+}
+
+// CaffeineBedtimeWarning flags a new log that leaves more than the user's bedtime limit of
+// caffeine active when they go to sleep.
+type CaffeineBedtimeWarning struct {
+	BedtimeAt         time.Time
+	ActiveAtBedtimeMg float64
+	BedtimeLimitMg    float64
 }
 
 // BeforeCreate ensures UUIDs are set.
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SafetyWarning records an intake-rate threshold crossed by a new hydration log, kept for
+// clinician review. Window is "hour" or "day" (trailing from the log's ConsumedAt) and Severity
+// is "caution" or "danger". WeightKg is the body weight the threshold was derived from.
+// HydrationLogID is cleared when the log is purged so the warning outlives it.
+type SafetyWarning struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt      time.Time
+	UserID         uuid.UUID  `gorm:"type:uuid;index:idx_safety_warnings_user_day,priority:1"`
+	HydrationLogID *uuid.UUID `gorm:"type:uuid;index"`
+	DailyKey       string     `gorm:"size:16;index:idx_safety_warnings_user_day,priority:2"`
+	TriggeredAt    time.Time
+	Window         string `gorm:"size:16"`
+	Severity       string `gorm:"size:16"`
+	IntakeMl       float64
+	ThresholdMl    float64
+	WeightKg       float64
+	Message        string `gorm:"size:255"`
+	User           User   `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate ensures UUIDs are set.
+func (s *SafetyWarning) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
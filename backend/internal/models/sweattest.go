@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SweatTest is a sweat-rate measurement: the athlete weighs in before and after a session and
+// the difference, corrected for what they drank and passed, is the sweat lost.
+//
+//	SweatLossMl = (PreWeightKg − PostWeightKg) · 1000 + FluidIntakeMl − UrineMl
+//	SweatRateLPerHour = SweatLossMl / 1000 / (DurationMinutes / 60)
+//
+// FluidIntakeMl is the volume of the hydration logs linked through HydrationLog.SweatTestID when
+// the test was recorded; HydrationLogs is only populated when preloaded. TemperatureC and
+// HumidityPercent describe the conditions.
+type SweatTest struct {
+	ID                uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	UserID            uuid.UUID `gorm:"type:uuid;index:idx_sweat_tests_user_day,priority:1"`
+	Activity          string    `gorm:"size:64"` // e.g. "running", matched against ExerciseSession.Type
+	StartedAt         time.Time
+	DurationMinutes   int
+	Timezone          string `gorm:"size:128"`
+	DailyKey          string `gorm:"size:16;index:idx_sweat_tests_user_day,priority:2"`
+	PreWeightKg       float64
+	PostWeightKg      float64
+	WeightUnit        string `gorm:"size:32"` // unit the weights were entered in, for display
+	FluidIntakeMl     float64
+	UrineMl           float64
+	SweatLossMl       float64
+	SweatRateLPerHour float64
+	TemperatureC      *float64
+	HumidityPercent   *float64
+	Notes             *string
+	HydrationLogs     []HydrationLog `gorm:"foreignKey:SweatTestID;constraint:OnDelete:SET NULL"`
+	User              User           `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate ensures UUIDs are set.
+func (s *SweatTest) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
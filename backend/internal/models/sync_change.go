@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Entity types and operations recorded in the sync journal.
+const (
+	SyncEntityProfile      = "profile"
+	SyncEntityDrink        = "drink"
+	SyncEntityHydrationLog = "hydration_log"
+	SyncEntityDailyGoal    = "daily_goal"
+
+	SyncOperationUpsert = "upsert"
+	SyncOperationDelete = "delete"
+)
+
+// SyncChange is an append-only journal entry written alongside every user-owned write.
+// Sequence is a per-user counter taken from User.SyncSequence under that user's row lock, so
+// a user's entries commit in sequence order. It doubles as the server-issued change cursor:
+// clients ask for everything after the last sequence they saw and learn about deletions as
+// tombstones. The global ID is not a safe cursor: values from a shared Postgres sequence can
+// commit out of order.
+type SyncChange struct {
+	ID         uint64 `gorm:"primaryKey;autoIncrement"`
+	CreatedAt  time.Time
+	UserID     uuid.UUID `gorm:"type:uuid;index;uniqueIndex:idx_sync_changes_user_sequence,priority:1"`
+	Sequence   uint64    `gorm:"uniqueIndex:idx_sync_changes_user_sequence,priority:2"`
+	EntityType string    `gorm:"size:32"`
+	EntityID   uuid.UUID `gorm:"type:uuid"`
+	Operation  string    `gorm:"size:16"`
+}
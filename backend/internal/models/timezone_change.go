@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TimezoneChange records every time a user's timezone moved, e.g. while travelling.
+//
+// Policy: each HydrationLog is keyed in the zone it was recorded in (HydrationLog.Timezone),
+// so changing the profile timezone never re-keys existing logs. Logs are only re-keyed when the
+// user explicitly corrects a wrong timezone, which is recorded here with Source "correction".
+// Source values: "profile", "correction".
+type TimezoneChange struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt        time.Time
+	UserID           uuid.UUID `gorm:"type:uuid;index"`
+	Timezone         string    `gorm:"size:128"`
+	PreviousTimezone string    `gorm:"size:128"`
+	EffectiveAt      time.Time `gorm:"index"`
+	Source           string    `gorm:"size:32;default:'profile'"`
+	User             User      `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate ensures UUIDs are set.
+func (t *TimezoneChange) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
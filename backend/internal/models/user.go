@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -37,11 +38,24 @@ type User struct {
 	LocationLongitude         *float64
 	DailyGoalLiters           float64
 	CustomGoalLiters          *float64
-	VolumeUnit                string `gorm:"size:32"`
-	TemperatureUnit           string `gorm:"size:32"`
-	ProgressWheelStyle        string `gorm:"size:64"`
+	GoalStrategy              string            `gorm:"size:32;default:'weight_based'"`
+	GoalStrategyInputs        datatypes.JSONMap `gorm:"type:jsonb"` // profile values used for DailyGoalLiters
+	VolumeUnit                string            `gorm:"size:32"`
+	TemperatureUnit           string            `gorm:"size:32"`
+	ProgressWheelStyle        string            `gorm:"size:64"`
 	WeatherAdjustmentsEnabled bool
 	TimezoneLastConfirmedAt   *time.Time
+	DayStartOffsetMinutes     int      `gorm:"default:0"`     // minutes after local midnight when the user's day begins
+	DayStartRekeyPending      bool     `gorm:"default:false"` // logs still keyed with a previous day start
+	WakeTimeMinutes           int      `gorm:"default:420"`   // local wall-clock minutes after midnight, drives pace tracking
+	SleepTimeMinutes          int      `gorm:"default:1380"`  // may be earlier than WakeTimeMinutes when sleeping after midnight
+	FluidRestrictionEnabled   bool     `gorm:"default:false"` // the daily goal is a medical upper limit, not a target
+	CaffeineLimitMg           *float64 // daily caffeine limit; nil means no limit
+	AlcoholLimitUnits         *float64 // daily alcohol limit in units; nil means no limit
+	CaffeineHalfLifeMinutes   int      `gorm:"default:300"`   // elimination half-life used for the active caffeine curve
+	CaffeineBedtimeLimitMg    float64  `gorm:"default:50"`    // active caffeine at bedtime above which a log warns; 0 disables
+	SweatRateGoalsEnabled     bool     `gorm:"default:false"` // exercise goal bonuses use the measured sweat rate instead of intensity estimates
+	SyncSequence              uint64   `gorm:"->;default:0"`  // last sync journal sequence; only advanced by the journal, never by saves
 	LastLoginAt               *time.Time
 	LoginAttempts             int `gorm:"default:0"`
 	LockedUntil               *time.Time
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WeightMeasurement is one entry in a user's body weight history. Goals for a day are computed
+// with the latest measurement on or before it, and the latest measurement overall is mirrored
+// onto User.WeightKg. Source values: "manual", "profile".
+type WeightMeasurement struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt  time.Time
+	UserID     uuid.UUID `gorm:"type:uuid;index:idx_weight_measurements_user_day,priority:1"`
+	WeightKg   float64
+	Unit       string `gorm:"size:32"` // unit the value was entered in, for display
+	MeasuredAt time.Time
+	Timezone   string `gorm:"size:128"`
+	DailyKey   string `gorm:"size:16;index:idx_weight_measurements_user_day,priority:2"`
+	Source     string `gorm:"size:32;default:'manual'"`
+	Notes      *string
+	User       User `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate ensures UUIDs are set.
+func (w *WeightMeasurement) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
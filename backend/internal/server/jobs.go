@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// rekeyInterval is how often logs are re-keyed for users who changed their day start.
+const rekeyInterval = time.Minute
+
+// startJobs launches background maintenance tasks that live as long as ctx.
+func (s *Server) startJobs(ctx context.Context) {
+	go s.runPeriodically(ctx, "purge deleted hydration logs", s.cfg.TrashPurgeInterval, func(ctx context.Context) error {
+		purged, err := s.hydration.PurgeDeletedHydrationLogs(ctx, time.Now().Add(-s.cfg.TrashRetention))
+		if err != nil {
+			return err
+		}
+		if purged > 0 {
+			s.logger.Info("purged deleted hydration logs", slog.Int64("count", purged))
+		}
+		return nil
+	})
+
+	go s.runPeriodically(ctx, "rekey hydration logs", rekeyInterval, func(ctx context.Context) error {
+		count, err := s.rollups.RekeyPending(ctx)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			s.logger.Info("rekeyed hydration logs", slog.Int("users", count))
+		}
+		return nil
+	})
+}
+
+// runPeriodically runs job immediately and then on every tick until ctx is cancelled.
+func (s *Server) runPeriodically(ctx context.Context, name string, interval time.Duration, job func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error("background job failed", slog.String("job", name), slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -20,20 +20,31 @@ import (
 )
 
 type Server struct {
-	cfg    config.Config
-	http   *http.Server
-	logger *slog.Logger
+	cfg       config.Config
+	http      *http.Server
+	logger    *slog.Logger
+	hydration *services.HydrationService
+	rollups   *services.RollupService
+	stopJobs  context.CancelFunc
 }
 
 func New(cfg config.Config, db *gorm.DB, logger *slog.Logger) *Server {
 	userService := services.NewUserService(db)
 	drinkService := services.NewDrinkService(db)
 	dailyGoalService := services.NewDailyGoalService(db)
-	hydrationService := services.NewHydrationService(db, dailyGoalService)
+	streakService := services.NewStreakService(db)
+	hydrationService := services.NewHydrationService(db, dailyGoalService, streakService)
 	authService := services.NewAuthService(db, cfg)
 	weatherService := services.NewWeatherService(db)
+	syncService := services.NewSyncService(db)
+	timezoneService := services.NewTimezoneService(db)
+	exerciseService := services.NewExerciseService(db)
+	caffeineService := services.NewCaffeineService(db)
+	containerService := services.NewContainerService(db, hydrationService)
+	weightService := services.NewWeightService(db)
+	sweatTestService := services.NewSweatTestService(db)
 
-	api := handlers.NewAPI(userService, drinkService, hydrationService, dailyGoalService, authService, weatherService, logger)
+	api := handlers.NewAPI(userService, drinkService, hydrationService, dailyGoalService, authService, weatherService, syncService, streakService, timezoneService, exerciseService, caffeineService, containerService, weightService, sweatTestService, logger)
 
 	r := chi.NewRouter()
 	configureMiddleware(r, cfg)
@@ -50,19 +61,28 @@ func New(cfg config.Config, db *gorm.DB, logger *slog.Logger) *Server {
 	}
 
 	return &Server{
-		cfg:    cfg,
-		http:   httpServer,
-		logger: logger,
+		cfg:       cfg,
+		http:      httpServer,
+		logger:    logger,
+		hydration: hydrationService,
+		rollups:   services.NewRollupService(db),
 	}
 }
 
 func (s *Server) Run() error {
+	jobsCtx, cancel := context.WithCancel(context.Background())
+	s.stopJobs = cancel
+	s.startJobs(jobsCtx)
+
 	s.logger.Info("server listening", slog.String("addr", s.http.Addr))
 	return s.http.ListenAndServe()
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.Info("server shutting down")
+	if s.stopJobs != nil {
+		s.stopJobs()
+	}
 	return s.http.Shutdown(ctx)
 }
 
@@ -114,20 +134,59 @@ func registerRoutes(r chi.Router, api *handlers.API, authMiddleware func(http.Ha
 				r.Delete("/", api.DeleteUserAccount)
 				r.Get("/export", api.ExportUserData)
 				r.Post("/import", api.ImportUserData)
+				r.Get("/sync", api.SyncChanges)
+				r.Get("/timezone/history", api.TimezoneHistory)
+				r.Post("/timezone/rekey/preview", api.PreviewTimezoneRekey)
+				r.Post("/timezone/rekey", api.ApplyTimezoneRekey)
 
 				r.Get("/drinks", api.ListDrinks)
 				r.Post("/drinks", api.CreateDrink)
 				r.Patch("/drinks/{drinkID}", api.UpdateDrink)
 				r.Delete("/drinks/{drinkID}", api.DeleteDrink)
 
+				r.Get("/containers", api.ListContainers)
+				r.Post("/containers", api.CreateContainer)
+				r.Patch("/containers/{containerID}", api.UpdateContainer)
+				r.Delete("/containers/{containerID}", api.DeleteContainer)
+				r.Post("/containers/{containerID}/fill", api.FillContainer)
+				r.Post("/containers/{containerID}/sips", api.SipFromContainer)
+
 				r.Get("/hydration/daily", api.DailySummary)
 				r.Get("/hydration/stats", api.HydrationStats)
+				r.Get("/hydration/logs", api.ListHydrationLogs)
 				r.Post("/hydration/logs", api.LogHydration)
+				r.Post("/hydration/logs/batch", api.LogHydrationBatch)
+				r.Get("/hydration/logs/trash", api.ListDeletedHydrationLogs)
+				r.Post("/hydration/logs/{logID}/restore", api.RestoreHydrationLog)
+				r.Patch("/hydration/logs/{logID}", api.UpdateHydrationLog)
 				r.Delete("/hydration/logs/{logID}", api.DeleteHydrationLog)
+				r.Get("/hydration/warnings", api.ListSafetyWarnings)
+				r.Get("/hydration/caffeine", api.CaffeineCurve)
+
+				r.Get("/hydration/streak", api.GetStreak)
+				r.Get("/hydration/streak/excused", api.ListExcusedDays)
+				r.Post("/hydration/streak/excused", api.SetExcusedDay)
+				r.Delete("/hydration/streak/excused", api.DeleteExcusedDay)
+
+				r.Get("/exercise", api.ListExerciseSessions)
+				r.Post("/exercise", api.CreateExerciseSession)
+				r.Patch("/exercise/{sessionID}", api.UpdateExerciseSession)
+				r.Delete("/exercise/{sessionID}", api.DeleteExerciseSession)
+				r.Get("/exercise/sweat-tests", api.ListSweatTests)
+				r.Post("/exercise/sweat-tests", api.CreateSweatTest)
+				r.Delete("/exercise/sweat-tests/{testID}", api.DeleteSweatTest)
+
+				r.Get("/weight", api.ListWeightMeasurements)
+				r.Post("/weight", api.CreateWeightMeasurement)
+				r.Delete("/weight/{measurementID}", api.DeleteWeightMeasurement)
 
 				r.Get("/hydration/goals/daily", api.GetDailyGoal)
 				r.Post("/hydration/goals/daily", api.SetDailyGoal)
 				r.Delete("/hydration/goals/daily", api.DeleteDailyGoal)
+				r.Get("/hydration/goals/rules", api.ListGoalRules)
+				r.Post("/hydration/goals/rules", api.CreateGoalRule)
+				r.Put("/hydration/goals/rules/{ruleID}", api.UpdateGoalRule)
+				r.Delete("/hydration/goals/rules/{ruleID}", api.DeleteGoalRule)
 
 				// Weather endpoints
 				r.Get("/weather/current", api.GetCurrentWeather)
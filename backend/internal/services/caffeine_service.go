@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/AD-Archer/archer-aqua/backend/internal/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Caffeine half-life bounds accepted on the profile. Healthy adults typically eliminate caffeine
+// with a half-life of 3 to 7 hours; pregnancy and some medications stretch it much further.
+const (
+	defaultCaffeineHalfLifeMinutes = 5 * 60
+	minCaffeineHalfLifeMinutes     = 60
+	maxCaffeineHalfLifeMinutes     = 12 * 60
+)
+
+// Curve resolution bounds, in minutes between points.
+const (
+	defaultCaffeineStepMinutes = 15
+	maxCaffeineStepMinutes     = 240
+)
+
+// caffeineLookbackHalfLives bounds how far back doses are loaded; after five half-lives less
+// than 4% of a dose remains.
+const caffeineLookbackHalfLives = 5
+
+// CaffeineService models active caffeine from the caffeine copied onto hydration logs.
+//
+// Each dose is treated as absorbed at ConsumedAt and eliminated exponentially with the user's
+// half-life: active(t) = Σ mg · 0.5^((t − consumedAt) / halfLife). Absorption takes roughly
+// 45 minutes in practice, so the curve peaks slightly early but the bedtime tail is accurate.
+type CaffeineService struct {
+	db *gorm.DB
+}
+
+func NewCaffeineService(db *gorm.DB) *CaffeineService {
+	return &CaffeineService{db: db}
+}
+
+type caffeineDose struct {
+	ConsumedAt time.Time
+	CaffeineMg float64
+}
+
+// Curve returns active caffeine across the user's day at stepMinutes resolution, starting at
+// the day boundary. A zero stepMinutes uses the default.
+func (s *CaffeineService) Curve(ctx context.Context, userID uuid.UUID, date string, stepMinutes int) (*dto.CaffeineCurveResponse, error) {
+	if stepMinutes == 0 {
+		stepMinutes = defaultCaffeineStepMinutes
+	}
+	if stepMinutes < 1 || stepMinutes > maxCaffeineStepMinutes {
+		return nil, fmt.Errorf("step must be between 1 and %d minutes", maxCaffeineStepMinutes)
+	}
+
+	user, err := s.fetchUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, loc, err := dayRange(s.db.WithContext(ctx), user, date)
+	if err != nil {
+		return nil, err
+	}
+	_, bedtime, err := wakeWindow(user, date, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	halfLife := caffeineHalfLife(user)
+	until := end
+	if bedtime.After(until) {
+		until = bedtime
+	}
+	doses, err := loadCaffeineDoses(s.db.WithContext(ctx), userID, start.Add(-caffeineLookbackHalfLives*halfLife), until)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.CaffeineCurveResponse{
+		Date:              date,
+		Timezone:          loc.String(),
+		HalfLifeMinutes:   int(halfLife / time.Minute),
+		StepMinutes:       stepMinutes,
+		BedtimeAt:         bedtime,
+		ActiveAtBedtimeMg: activeCaffeine(doses, bedtime, halfLife),
+		BedtimeLimitMg:    user.CaffeineBedtimeLimitMg,
+		Points:            make([]dto.CaffeinePoint, 0),
+	}
+	for _, dose := range doses {
+		if !dose.ConsumedAt.Before(start) && !dose.ConsumedAt.After(end) {
+			response.ConsumedMg += dose.CaffeineMg
+		}
+	}
+
+	step := time.Duration(stepMinutes) * time.Minute
+	for at := start; !at.After(end); at = at.Add(step) {
+		active := activeCaffeine(doses, at, halfLife)
+		response.Points = append(response.Points, dto.CaffeinePoint{At: at.In(loc), ActiveMg: active})
+		if active > response.PeakMg {
+			peakAt := at.In(loc)
+			response.PeakMg = active
+			response.PeakAt = &peakAt
+		}
+	}
+
+	return response, nil
+}
+
+// caffeineBedtimeWarning reports whether the user will still have more than their bedtime limit
+// of caffeine active at the first bedtime after the log. It returns nil when the log carries no
+// caffeine, the limit is disabled, or the projection stays under it. Callers pass the
+// transaction that stored the log so the projection includes it.
+func caffeineBedtimeWarning(tx *gorm.DB, user *models.User, logEntry *models.HydrationLog) (*models.CaffeineBedtimeWarning, error) {
+	if logEntry.CaffeineMg <= 0 || user.CaffeineBedtimeLimitMg <= 0 {
+		return nil, nil
+	}
+
+	loc, err := utils.LoadLocation(defaultString(logEntry.Timezone, user.Timezone))
+	if err != nil {
+		return nil, err
+	}
+	_, bedtime, err := wakeWindow(user, logEntry.DailyKey, loc)
+	if err != nil {
+		return nil, err
+	}
+	if bedtime.Before(logEntry.ConsumedAt) {
+		// Drunk after going to bed: the next night is the one affected.
+		day, err := time.Parse(time.DateOnly, logEntry.DailyKey)
+		if err != nil {
+			return nil, fmt.Errorf("parse daily key: %w", err)
+		}
+		if _, bedtime, err = wakeWindow(user, day.AddDate(0, 0, 1).Format(time.DateOnly), loc); err != nil {
+			return nil, err
+		}
+	}
+
+	halfLife := caffeineHalfLife(user)
+	doses, err := loadCaffeineDoses(tx, user.ID, bedtime.Add(-caffeineLookbackHalfLives*halfLife), bedtime)
+	if err != nil {
+		return nil, err
+	}
+
+	active := activeCaffeine(doses, bedtime, halfLife)
+	if active <= user.CaffeineBedtimeLimitMg {
+		return nil, nil
+	}
+
+	return &models.CaffeineBedtimeWarning{
+		BedtimeAt:         bedtime,
+		ActiveAtBedtimeMg: active,
+		BedtimeLimitMg:    user.CaffeineBedtimeLimitMg,
+	}, nil
+}
+
+func caffeineHalfLife(user *models.User) time.Duration {
+	minutes := user.CaffeineHalfLifeMinutes
+	if minutes < minCaffeineHalfLifeMinutes || minutes > maxCaffeineHalfLifeMinutes {
+		minutes = defaultCaffeineHalfLifeMinutes
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// activeCaffeine sums what remains at `at` of every dose consumed up to then.
+func activeCaffeine(doses []caffeineDose, at time.Time, halfLife time.Duration) float64 {
+	active := 0.0
+	for _, dose := range doses {
+		if dose.ConsumedAt.After(at) {
+			continue
+		}
+		active += dose.CaffeineMg * math.Pow(0.5, float64(at.Sub(dose.ConsumedAt))/float64(halfLife))
+	}
+	return active
+}
+
+func loadCaffeineDoses(db *gorm.DB, userID uuid.UUID, from, to time.Time) ([]caffeineDose, error) {
+	var doses []caffeineDose
+	if err := db.Model(&models.HydrationLog{}).
+		Select("consumed_at, caffeine_mg").
+		Where("user_id = ? AND caffeine_mg > 0 AND consumed_at >= ? AND consumed_at <= ?", userID, from, to).
+		Order("consumed_at ASC").
+		Scan(&doses).Error; err != nil {
+		return nil, fmt.Errorf("load caffeine doses: %w", err)
+	}
+	return doses, nil
+}
+
+func (s *CaffeineService) fetchUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).First(&user, "id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, fmt.Errorf("fetch user: %w", err)
+	}
+	return &user, nil
+}
@@ -0,0 +1,311 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/AD-Archer/archer-aqua/backend/internal/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrContainerNotFound = errors.New("container not found")
+	ErrContainerArchived = errors.New("container is archived")
+	ErrInvalidSipAmount  = errors.New("give exactly one of fraction (0-1] or percent (0-100]")
+)
+
+// containerColorPattern accepts #RGB and #RRGGBB colors.
+var containerColorPattern = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ContainerService manages the user's bottles and cups. Sips are logged through
+// HydrationService so they get the same validation, idempotency and safety checks as any
+// other log, then drain the container's fill level.
+type ContainerService struct {
+	db           *gorm.DB
+	hydrationSvc *HydrationService
+}
+
+func NewContainerService(db *gorm.DB, hydrationSvc *HydrationService) *ContainerService {
+	return &ContainerService{db: db, hydrationSvc: hydrationSvc}
+}
+
+// ListContainers returns the user's containers by name; archived ones only when asked.
+func (s *ContainerService) ListContainers(ctx context.Context, userID uuid.UUID, includeArchived bool) ([]models.Container, error) {
+	return listContainers(s.db.WithContext(ctx), userID, includeArchived)
+}
+
+func (s *ContainerService) CreateContainer(ctx context.Context, userID uuid.UUID, input dto.CreateContainerRequest) (*models.Container, error) {
+	capacityMl, err := utils.ConvertVolumeToMl(input.Capacity.Value, input.Capacity.Unit)
+	if err != nil {
+		return nil, err
+	}
+
+	container := models.Container{
+		UserID:         userID,
+		Name:           strings.TrimSpace(input.Name),
+		CapacityMl:     capacityMl,
+		ColorHex:       input.ColorHex,
+		DefaultDrinkID: input.DefaultDrinkID,
+	}
+	if container.ColorHex != nil && strings.TrimSpace(*container.ColorHex) == "" {
+		container.ColorHex = nil
+	}
+	if err := s.validateContainer(ctx, &container); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WithContext(ctx).Create(&container).Error; err != nil {
+		return nil, fmt.Errorf("create container: %w", err)
+	}
+	return &container, nil
+}
+
+func (s *ContainerService) UpdateContainer(ctx context.Context, userID, containerID uuid.UUID, input dto.UpdateContainerRequest) (*models.Container, error) {
+	container, err := fetchOwnedContainer(s.db.WithContext(ctx), userID, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		container.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Capacity != nil {
+		capacityMl, err := utils.ConvertVolumeToMl(input.Capacity.Value, input.Capacity.Unit)
+		if err != nil {
+			return nil, err
+		}
+		container.CapacityMl = capacityMl
+		if container.CurrentFillMl > capacityMl {
+			container.CurrentFillMl = capacityMl
+		}
+	}
+	if input.ColorHex != nil {
+		if strings.TrimSpace(*input.ColorHex) == "" {
+			container.ColorHex = nil
+		} else {
+			container.ColorHex = input.ColorHex
+		}
+	}
+	if input.DefaultDrinkID != nil {
+		container.DefaultDrinkID = input.DefaultDrinkID
+	}
+	if input.ClearDefaultDrink {
+		container.DefaultDrinkID = nil
+	}
+	if input.Archived != nil {
+		if *input.Archived {
+			now := time.Now().UTC()
+			container.ArchivedAt = &now
+		} else {
+			container.ArchivedAt = nil
+		}
+	}
+	if err := s.validateContainer(ctx, container); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WithContext(ctx).Save(container).Error; err != nil {
+		return nil, fmt.Errorf("update container: %w", err)
+	}
+	return container, nil
+}
+
+// DeleteContainer removes a container, or archives it when logs still reference it.
+func (s *ContainerService) DeleteContainer(ctx context.Context, userID, containerID uuid.UUID) error {
+	container, err := fetchOwnedContainer(s.db.WithContext(ctx), userID, containerID)
+	if err != nil {
+		return err
+	}
+
+	var logCount int64
+	if err := s.db.WithContext(ctx).Unscoped().Model(&models.HydrationLog{}).
+		Where("container_id = ?", containerID).
+		Count(&logCount).Error; err != nil {
+		return fmt.Errorf("check container usage: %w", err)
+	}
+
+	if logCount > 0 {
+		now := time.Now().UTC()
+		container.ArchivedAt = &now
+		if err := s.db.WithContext(ctx).Save(container).Error; err != nil {
+			return fmt.Errorf("archive container: %w", err)
+		}
+		return nil
+	}
+
+	if err := s.db.WithContext(ctx).Delete(container).Error; err != nil {
+		return fmt.Errorf("delete container: %w", err)
+	}
+	return nil
+}
+
+// FillContainer sets the fill level, to capacity when no volume is given.
+func (s *ContainerService) FillContainer(ctx context.Context, userID, containerID uuid.UUID, input dto.FillContainerRequest) (*models.Container, error) {
+	container, err := fetchOwnedContainer(s.db.WithContext(ctx), userID, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	fillMl := container.CapacityMl
+	if input.Volume != nil {
+		if fillMl, err = utils.ConvertVolumeToMl(input.Volume.Value, input.Volume.Unit); err != nil {
+			return nil, err
+		}
+		if fillMl < 0 || fillMl > container.CapacityMl {
+			return nil, fmt.Errorf("fill volume must be between 0 and the container capacity of %.0f ml", container.CapacityMl)
+		}
+	}
+
+	now := time.Now().UTC()
+	container.CurrentFillMl = fillMl
+	container.FilledAt = &now
+	if err := s.db.WithContext(ctx).Save(container).Error; err != nil {
+		return nil, fmt.Errorf("fill container: %w", err)
+	}
+	return container, nil
+}
+
+// Sip logs part of the container's capacity as a hydration log against it and returns the
+// log together with the drained container.
+func (s *ContainerService) Sip(ctx context.Context, userID, containerID uuid.UUID, input dto.ContainerSipRequest) (*models.HydrationLog, *models.Container, error) {
+	container, err := fetchActiveContainer(s.db.WithContext(ctx), userID, containerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var fraction float64
+	switch {
+	case input.Fraction != nil && input.Percent == nil:
+		fraction = *input.Fraction
+	case input.Percent != nil && input.Fraction == nil:
+		fraction = *input.Percent / 100
+	default:
+		return nil, nil, ErrInvalidSipAmount
+	}
+	if fraction <= 0 || fraction > 1 {
+		return nil, nil, ErrInvalidSipAmount
+	}
+
+	logEntry, err := s.hydrationSvc.LogHydration(ctx, userID, dto.LogHydrationRequest{
+		DrinkID:        input.DrinkID,
+		ContainerID:    &container.ID,
+		Volume:         dto.VolumePayload{Value: container.CapacityMl * fraction, Unit: "ml"},
+		ConsumedAt:     input.ConsumedAt,
+		Timezone:       input.Timezone,
+		Source:         defaultString(input.Source, "container"),
+		Notes:          input.Notes,
+		IdempotencyKey: input.IdempotencyKey,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	container, err = fetchOwnedContainer(s.db.WithContext(ctx), userID, containerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return logEntry, container, nil
+}
+
+func (s *ContainerService) validateContainer(ctx context.Context, container *models.Container) error {
+	if container.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if container.CapacityMl <= 0 {
+		return fmt.Errorf("capacity must be positive")
+	}
+	if container.ColorHex != nil && !containerColorPattern.MatchString(*container.ColorHex) {
+		return fmt.Errorf("colorHex must be #RGB or #RRGGBB")
+	}
+	if container.DefaultDrinkID != nil {
+		var count int64
+		if err := s.db.WithContext(ctx).Model(&models.Drink{}).
+			Where("id = ? AND (user_id IS NULL OR user_id = ?)", *container.DefaultDrinkID, container.UserID).
+			Count(&count).Error; err != nil {
+			return fmt.Errorf("check default drink: %w", err)
+		}
+		if count == 0 {
+			return fmt.Errorf("drink not available")
+		}
+	}
+	return nil
+}
+
+func listContainers(db *gorm.DB, userID uuid.UUID, includeArchived bool) ([]models.Container, error) {
+	query := db.Where("user_id = ?", userID)
+	if !includeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+
+	var containers []models.Container
+	if err := query.Order("name ASC").Find(&containers).Error; err != nil {
+		return nil, fmt.Errorf("list containers: %w", err)
+	}
+	return containers, nil
+}
+
+func fetchOwnedContainer(db *gorm.DB, userID, containerID uuid.UUID) (*models.Container, error) {
+	var container models.Container
+	if err := db.First(&container, "id = ? AND user_id = ?", containerID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrContainerNotFound
+		}
+		return nil, fmt.Errorf("fetch container: %w", err)
+	}
+	return &container, nil
+}
+
+// fetchActiveContainer is fetchOwnedContainer for containers that can still be drunk from.
+func fetchActiveContainer(db *gorm.DB, userID, containerID uuid.UUID) (*models.Container, error) {
+	container, err := fetchOwnedContainer(db, userID, containerID)
+	if err != nil {
+		return nil, err
+	}
+	if container.ArchivedAt != nil {
+		return nil, ErrContainerArchived
+	}
+	return container, nil
+}
+
+// drainContainer takes a new log's volume out of its container, never below empty.
+func drainContainer(tx *gorm.DB, logEntry *models.HydrationLog) error {
+	return changeContainerFill(tx, logEntry.ContainerID, logEntry.VolumeMl)
+}
+
+// refillContainer puts a removed log's volume back into its container, never above capacity.
+func refillContainer(tx *gorm.DB, logEntry *models.HydrationLog) error {
+	return changeContainerFill(tx, logEntry.ContainerID, -logEntry.VolumeMl)
+}
+
+// updateContainerFills moves an edited log's volume between containers: the previous container
+// gets the old volume back and the current one loses the new volume.
+func updateContainerFills(tx *gorm.DB, previous, current *models.HydrationLog) error {
+	if previous.ContainerID != nil && current.ContainerID != nil && *previous.ContainerID == *current.ContainerID {
+		return changeContainerFill(tx, current.ContainerID, current.VolumeMl-previous.VolumeMl)
+	}
+	if err := refillContainer(tx, previous); err != nil {
+		return err
+	}
+	return drainContainer(tx, current)
+}
+
+// changeContainerFill drains drainMl from a container, or refills it when negative, keeping the
+// fill level between empty and capacity. A nil container is ignored.
+func changeContainerFill(tx *gorm.DB, containerID *uuid.UUID, drainMl float64) error {
+	if containerID == nil || drainMl == 0 {
+		return nil
+	}
+	if err := tx.Model(&models.Container{}).
+		Where("id = ?", *containerID).
+		Update("current_fill_ml", gorm.Expr("LEAST(GREATEST(current_fill_ml - ?, 0), capacity_ml)", drainMl)).Error; err != nil {
+		return fmt.Errorf("update container fill: %w", err)
+	}
+	return nil
+}
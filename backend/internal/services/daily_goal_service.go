@@ -3,10 +3,13 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/AD-Archer/archer-aqua/backend/internal/utils"
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -19,34 +22,53 @@ func NewDailyGoalService(db *gorm.DB) *DailyGoalService {
 	return &DailyGoalService{db: db}
 }
 
-var ErrDailyGoalNotFound = errors.New("daily goal not found")
+var (
+	ErrDailyGoalNotFound = errors.New("daily goal not found")
+	ErrPastDailyGoal     = errors.New("goals for past days cannot be deleted; set a new goal instead")
+)
 
 // SetDailyGoal sets or updates the hydration goal for a specific date.
+// goalMl becomes the day's base goal; enabled adjusters (e.g. weather) still add on top.
 func (s *DailyGoalService) SetDailyGoal(ctx context.Context, userID uuid.UUID, date string, goalMl float64) (*models.DailyGoal, error) {
+	var user models.User
+	if err := s.db.First(&user, "id = ?", userID).Error; err != nil {
+		return nil, fmt.Errorf("fetch user: %w", err)
+	}
+
 	var dailyGoal models.DailyGoal
 
 	// Try to find existing goal for this date
 	err := s.db.Where("user_id = ? AND date = ?", userID, date).First(&dailyGoal).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			// Create new daily goal
-			dailyGoal = models.DailyGoal{
-				UserID: userID,
-				Date:   date,
-				GoalMl: goalMl,
-			}
-			if err := s.db.Create(&dailyGoal).Error; err != nil {
-				return nil, err
-			}
-			return &dailyGoal, nil
+		// Create new daily goal
+		dailyGoal = models.DailyGoal{
+			UserID: userID,
+			Date:   date,
 		}
-		return nil, err
 	}
 
-	// Update existing goal
-	dailyGoal.GoalMl = goalMl
+	dailyGoal.BaseGoalMl = goalMl
+	dailyGoal.BaseComponents = []models.GoalAdjustment{
+		{Source: models.DailyGoalSourceManual, Reason: "manual_override", AmountMl: goalMl},
+	}
+	dailyGoal.Source = models.DailyGoalSourceManual
 	dailyGoal.UpdatedAt = time.Now()
-	if err := s.db.Save(&dailyGoal).Error; err != nil {
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := applyGoalAdjustments(tx, &user, &dailyGoal); err != nil {
+			return err
+		}
+		if err := tx.Save(&dailyGoal).Error; err != nil {
+			return err
+		}
+		if err := refreshDailyRollups(tx, userID, date); err != nil {
+			return err
+		}
+		return recordSyncChange(tx, userID, models.SyncEntityDailyGoal, dailyGoal.ID, models.SyncOperationUpsert)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -56,53 +78,248 @@ func (s *DailyGoalService) SetDailyGoal(ctx context.Context, userID uuid.UUID, d
 // GetDailyGoal retrieves the goal for a specific date.
 // If no specific goal is set, returns the user's default daily goal.
 func (s *DailyGoalService) GetDailyGoal(ctx context.Context, userID uuid.UUID, date string) (float64, error) {
+	return resolveDailyGoalMl(s.db, userID, date)
+}
+
+// resolveDailyGoalMl implements GetDailyGoal against any handle so it can run inside a transaction.
+func resolveDailyGoalMl(db *gorm.DB, userID uuid.UUID, date string) (float64, error) {
+	goal, err := resolveDailyGoal(db, userID, date)
+	if err != nil {
+		return 0, err
+	}
+	return goal.GoalMl, nil
+}
+
+// resolveDailyGoal returns the stored goal for date, or an unsaved one built from the user's
+// goal rules and profile when the day has none.
+func resolveDailyGoal(db *gorm.DB, userID uuid.UUID, date string) (*models.DailyGoal, error) {
 	var dailyGoal models.DailyGoal
 
-	err := s.db.Where("user_id = ? AND date = ?", userID, date).First(&dailyGoal).Error
+	err := db.Where("user_id = ? AND date = ?", userID, date).First(&dailyGoal).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			// No specific goal set, return user's default goal
+			// No specific goal set, fall back to the user's schedule or default goal
 			var user models.User
-			if err := s.db.Where("id = ?", userID).First(&user).Error; err != nil {
-				return 0, err
+			if err := db.Where("id = ?", userID).First(&user).Error; err != nil {
+				return nil, err
 			}
-			goalMl := user.DailyGoalLiters * 1000
-			if goalMl == 0 {
-				goalMl = 2000 // Default fallback
+			schedule, err := loadGoalSchedule(db, userID)
+			if err != nil {
+				return nil, err
 			}
-			return goalMl, nil
+			return scheduledDailyGoal(&user, schedule, date), nil
 		}
-		return 0, err
+		return nil, err
 	}
 
-	return dailyGoal.GoalMl, nil
+	return &dailyGoal, nil
 }
 
-// GetDailyGoals retrieves goals for a date range.
-func (s *DailyGoalService) GetDailyGoals(ctx context.Context, userID uuid.UUID, startDate, endDate string) (map[string]float64, error) {
-	var dailyGoals []models.DailyGoal
+// scheduledDailyGoal is the goal a day gets from goal rules and the profile, before any
+// adjustments.
+func scheduledDailyGoal(user *models.User, schedule *goalSchedule, date string) *models.DailyGoal {
+	goalMl, components := scheduledBaseGoal(user, schedule, date)
+	return &models.DailyGoal{
+		UserID:         user.ID,
+		Date:           date,
+		GoalMl:         goalMl,
+		BaseGoalMl:     goalMl,
+		BaseComponents: components,
+		Source:         models.DailyGoalSourceSnapshot,
+	}
+}
 
-	err := s.db.Where("user_id = ? AND date >= ? AND date <= ?", userID, startDate, endDate).Find(&dailyGoals).Error
-	if err != nil {
+// GetDailyGoalDetail resolves the goal for one date including its breakdown.
+func (s *DailyGoalService) GetDailyGoalDetail(ctx context.Context, userID uuid.UUID, date string) (*models.DailyGoal, error) {
+	return resolveDailyGoal(s.db.WithContext(ctx), userID, date)
+}
+
+// GetDailyGoals resolves the goal with its breakdown for every date in an inclusive range,
+// keyed by date. Days without a stored goal get the unsaved goal resolveDailyGoal would build
+// for them, so range and single-day lookups agree.
+func (s *DailyGoalService) GetDailyGoals(ctx context.Context, userID uuid.UUID, startDate, endDate string) (map[string]models.DailyGoal, error) {
+	db := s.db.WithContext(ctx)
+
+	var dailyGoals []models.DailyGoal
+	if err := db.Where("user_id = ? AND date >= ? AND date <= ?", userID, startDate, endDate).Find(&dailyGoals).Error; err != nil {
 		return nil, err
 	}
 
-	goals := make(map[string]float64)
+	goals := make(map[string]models.DailyGoal, len(dailyGoals))
 	for _, goal := range dailyGoals {
-		goals[goal.Date] = goal.GoalMl
+		goals[goal.Date] = goal
+	}
+
+	var user models.User
+	if err := db.First(&user, "id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	schedule, err := loadGoalSchedule(db, userID)
+	if err != nil {
+		return nil, err
+	}
+	start, err := time.Parse(time.DateOnly, startDate)
+	if err != nil {
+		return nil, fmt.Errorf("parse start date: %w", err)
+	}
+	end, err := time.Parse(time.DateOnly, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("parse end date: %w", err)
+	}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		key := day.Format(time.DateOnly)
+		if _, exists := goals[key]; exists {
+			continue
+		}
+		goals[key] = *scheduledDailyGoal(&user, schedule, key)
 	}
 
 	return goals, nil
 }
 
-// DeleteDailyGoal removes a specific daily goal.
+// DeleteDailyGoal removes the goal of today or a future day, which then follows the schedule
+// again. Past days are refused: their goal is part of the history, and the base goal a manual
+// override replaced is not kept, so it could only be rebuilt from the current profile.
 func (s *DailyGoalService) DeleteDailyGoal(ctx context.Context, userID uuid.UUID, date string) error {
-	result := s.db.Where("user_id = ? AND date = ?", userID, date).Delete(&models.DailyGoal{})
-	if result.Error != nil {
-		return result.Error
+	var user models.User
+	if err := s.db.First(&user, "id = ?", userID).Error; err != nil {
+		return fmt.Errorf("fetch user: %w", err)
 	}
-	if result.RowsAffected == 0 {
+	loc, err := utils.LoadLocation(user.Timezone)
+	if err != nil {
+		return err
+	}
+	if date < utils.DailyKey(time.Now(), loc, user.DayStartOffsetMinutes) {
+		return ErrPastDailyGoal
+	}
+
+	var dailyGoals []models.DailyGoal
+	if err := s.db.Where("user_id = ? AND date = ?", userID, date).Find(&dailyGoals).Error; err != nil {
+		return err
+	}
+	if len(dailyGoals) == 0 {
 		return ErrDailyGoalNotFound
 	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		for _, dailyGoal := range dailyGoals {
+			if err := tx.Delete(&models.DailyGoal{}, "id = ?", dailyGoal.ID).Error; err != nil {
+				return err
+			}
+			if err := recordSyncChange(tx, userID, models.SyncEntityDailyGoal, dailyGoal.ID, models.SyncOperationDelete); err != nil {
+				return err
+			}
+		}
+		return refreshDailyRollups(tx, userID, date)
+	})
+}
+
+// snapshotDailyGoals freezes the user's current scheduled goal (goal rule or profile) onto each
+// date that has no DailyGoal row yet. Callers pass their transaction and only dates that saw activity.
+func snapshotDailyGoals(tx *gorm.DB, userID uuid.UUID, dates ...string) error {
+	if len(dates) == 0 {
+		return nil
+	}
+
+	var existing []string
+	if err := tx.Model(&models.DailyGoal{}).
+		Where("user_id = ? AND date IN ?", userID, dates).
+		Pluck("date", &existing).Error; err != nil {
+		return fmt.Errorf("fetch daily goals: %w", err)
+	}
+
+	covered := make(map[string]bool, len(existing)+len(dates))
+	for _, date := range existing {
+		covered[date] = true
+	}
+
+	var user *models.User
+	var schedule *goalSchedule
+	for _, date := range dates {
+		if covered[date] {
+			continue
+		}
+		covered[date] = true
+
+		if user == nil {
+			user = &models.User{}
+			if err := tx.First(user, "id = ?", userID).Error; err != nil {
+				return fmt.Errorf("fetch user: %w", err)
+			}
+			var err error
+			if schedule, err = loadGoalSchedule(tx, userID); err != nil {
+				return err
+			}
+		}
+
+		snapshot := *scheduledDailyGoal(user, schedule, date)
+		if err := applyGoalAdjustments(tx, user, &snapshot); err != nil {
+			return err
+		}
+		if err := tx.Create(&snapshot).Error; err != nil {
+			return fmt.Errorf("snapshot daily goal %s: %w", date, err)
+		}
+		if err := recordSyncChange(tx, userID, models.SyncEntityDailyGoal, snapshot.ID, models.SyncOperationUpsert); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+// resnapshotDailyGoal moves an existing snapshot for date onto the user's current scheduled
+// goal and re-runs the goal adjusters. Manual base goals are left alone. resnapshotUpcomingGoals
+// uses it after profile and goal rule edits so that upcoming days follow the new goal while
+// earlier days stay frozen.
+func resnapshotDailyGoal(tx *gorm.DB, user *models.User, date string) error {
+	schedule, err := loadGoalSchedule(tx, user.ID)
+	if err != nil {
+		return err
+	}
+	baseGoalMl, components := scheduledBaseGoal(user, schedule, date)
+	if err := tx.Model(&models.DailyGoal{}).
+		Where("user_id = ? AND date = ? AND source = ?", user.ID, date, models.DailyGoalSourceSnapshot).
+		Updates(map[string]interface{}{
+			"base_goal_ml":    baseGoalMl,
+			"base_components": datatypes.NewJSONSlice(components),
+		}).Error; err != nil {
+		return fmt.Errorf("update daily goal snapshot: %w", err)
+	}
+	return reapplyGoalAdjustments(tx, user, date)
+}
+
+// refreshGoalAdjustments re-runs the goal adjusters after one of their inputs changed (weather,
+// exercise, ...), snapshotting a goal first for days that have none. Base goals are kept.
+func refreshGoalAdjustments(tx *gorm.DB, user *models.User, dates ...string) error {
+	if err := snapshotDailyGoals(tx, user.ID, dates...); err != nil {
+		return err
+	}
+	return reapplyGoalAdjustments(tx, user, dates...)
+}
+
+// reapplyGoalAdjustments recomputes the adjustments of every existing goal on dates.
+func reapplyGoalAdjustments(tx *gorm.DB, user *models.User, dates ...string) error {
+	if len(dates) == 0 {
+		return nil
+	}
+
+	var goals []models.DailyGoal
+	if err := tx.Where("user_id = ? AND date IN ?", user.ID, dates).
+		Find(&goals).Error; err != nil {
+		return fmt.Errorf("fetch daily goals: %w", err)
+	}
+
+	for _, goal := range goals {
+		if err := applyGoalAdjustments(tx, user, &goal); err != nil {
+			return err
+		}
+		if err := tx.Save(&goal).Error; err != nil {
+			return fmt.Errorf("update daily goal: %w", err)
+		}
+		if err := recordSyncChange(tx, user.ID, models.SyncEntityDailyGoal, goal.ID, models.SyncOperationUpsert); err != nil {
+			return err
+		}
+	}
+
+	return refreshDailyRollups(tx, user.ID, dates...)
+}
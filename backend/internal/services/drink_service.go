@@ -47,21 +47,34 @@ func (s *DrinkService) CreateDrink(ctx context.Context, userID uuid.UUID, input
 	}
 
 	drink := models.Drink{
-		UserID:              &userID,
-		Name:                strings.TrimSpace(input.Name),
-		Type:                defaultString(input.Type, "beverage"),
-		HydrationMultiplier: input.HydrationMultiplier,
-		DefaultVolumeMl:     defaultVolumeMl,
-		ColorHex:            input.ColorHex,
-		Source:              defaultString(input.Source, "custom"),
+		UserID:               &userID,
+		Name:                 strings.TrimSpace(input.Name),
+		Type:                 defaultString(input.Type, "beverage"),
+		HydrationMultiplier:  input.HydrationMultiplier,
+		DefaultVolumeMl:      defaultVolumeMl,
+		ColorHex:             input.ColorHex,
+		Source:               defaultString(input.Source, "custom"),
+		WaterContentRatio:    input.WaterContentRatio,
+		CaffeineMgPer100Ml:   input.CaffeineMgPer100Ml,
+		SugarGPer100Ml:       input.SugarGPer100Ml,
+		AlcoholUnitsPer100Ml: input.AlcoholUnitsPer100Ml,
 	}
 
 	if drink.HydrationMultiplier <= 0 {
 		drink.HydrationMultiplier = 1.0
 	}
+	if err := validateDrinkNutrients(&drink); err != nil {
+		return nil, err
+	}
 
-	if err := s.db.WithContext(ctx).Create(&drink).Error; err != nil {
-		return nil, fmt.Errorf("create drink: %w", err)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&drink).Error; err != nil {
+			return fmt.Errorf("create drink: %w", err)
+		}
+		return recordSyncChange(tx, userID, models.SyncEntityDrink, drink.ID, models.SyncOperationUpsert)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &drink, nil
@@ -104,9 +117,30 @@ func (s *DrinkService) UpdateDrink(ctx context.Context, userID, drinkID uuid.UUI
 			drink.ArchivedAt = nil
 		}
 	}
+	if input.WaterContentRatio != nil {
+		drink.WaterContentRatio = *input.WaterContentRatio
+	}
+	if input.CaffeineMgPer100Ml != nil {
+		drink.CaffeineMgPer100Ml = *input.CaffeineMgPer100Ml
+	}
+	if input.SugarGPer100Ml != nil {
+		drink.SugarGPer100Ml = *input.SugarGPer100Ml
+	}
+	if input.AlcoholUnitsPer100Ml != nil {
+		drink.AlcoholUnitsPer100Ml = *input.AlcoholUnitsPer100Ml
+	}
+	if err := validateDrinkNutrients(drink); err != nil {
+		return nil, err
+	}
 
-	if err := s.db.WithContext(ctx).Save(drink).Error; err != nil {
-		return nil, fmt.Errorf("update drink: %w", err)
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(drink).Error; err != nil {
+			return fmt.Errorf("update drink: %w", err)
+		}
+		return recordSyncChange(tx, userID, models.SyncEntityDrink, drink.ID, models.SyncOperationUpsert)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return drink, nil
@@ -119,9 +153,9 @@ func (s *DrinkService) DeleteDrink(ctx context.Context, userID, drinkID uuid.UUI
 		return err
 	}
 
-	// Check if the drink is being used in any hydration logs
+	// Check if the drink is being used in any hydration logs, including ones in the trash
 	var logCount int64
-	if err := s.db.WithContext(ctx).Model(&models.HydrationLog{}).
+	if err := s.db.WithContext(ctx).Unscoped().Model(&models.HydrationLog{}).
 		Where("drink_id = ?", drinkID).
 		Count(&logCount).Error; err != nil {
 		return fmt.Errorf("check drink usage: %w", err)
@@ -131,18 +165,21 @@ func (s *DrinkService) DeleteDrink(ctx context.Context, userID, drinkID uuid.UUI
 	if logCount > 0 {
 		now := time.Now().UTC()
 		drink.ArchivedAt = &now
-		if err := s.db.WithContext(ctx).Save(drink).Error; err != nil {
-			return fmt.Errorf("archive drink: %w", err)
-		}
-		return nil
+		return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Save(drink).Error; err != nil {
+				return fmt.Errorf("archive drink: %w", err)
+			}
+			return recordSyncChange(tx, userID, models.SyncEntityDrink, drink.ID, models.SyncOperationUpsert)
+		})
 	}
 
 	// If no logs exist, we can safely delete the drink
-	if err := s.db.WithContext(ctx).Delete(drink).Error; err != nil {
-		return fmt.Errorf("delete drink: %w", err)
-	}
-
-	return nil
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(drink).Error; err != nil {
+			return fmt.Errorf("delete drink: %w", err)
+		}
+		return recordSyncChange(tx, userID, models.SyncEntityDrink, drink.ID, models.SyncOperationDelete)
+	})
 }
 
 func (s *DrinkService) getOwnedDrink(ctx context.Context, userID, drinkID uuid.UUID) (*models.Drink, error) {
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/AD-Archer/archer-aqua/backend/internal/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Exercise intensities accepted on a session.
+const (
+	ExerciseIntensityLow      = "low"
+	ExerciseIntensityModerate = "moderate"
+	ExerciseIntensityHigh     = "high"
+)
+
+// maxExerciseMinutes bounds a single session to one day.
+const maxExerciseMinutes = 24 * 60
+
+var (
+	ErrExerciseSessionNotFound  = errors.New("exercise session not found")
+	ErrInvalidExerciseIntensity = errors.New("intensity must be one of low, moderate, high")
+)
+
+// ExerciseService manages exercise sessions. Every write re-runs the goal adjusters for the
+// affected days so the session's bonus lands in that day's DailyGoal.
+type ExerciseService struct {
+	db *gorm.DB
+}
+
+func NewExerciseService(db *gorm.DB) *ExerciseService {
+	return &ExerciseService{db: db}
+}
+
+// ListSessions returns sessions between two inclusive daily keys; empty bounds are open.
+func (s *ExerciseService) ListSessions(ctx context.Context, userID uuid.UUID, from, to string) ([]models.ExerciseSession, error) {
+	query := s.db.WithContext(ctx).Where("user_id = ?", userID)
+	if from != "" {
+		query = query.Where("daily_key >= ?", from)
+	}
+	if to != "" {
+		query = query.Where("daily_key <= ?", to)
+	}
+
+	var sessions []models.ExerciseSession
+	if err := query.Order("started_at DESC").Find(&sessions).Error; err != nil {
+		return nil, fmt.Errorf("list exercise sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+func (s *ExerciseService) CreateSession(ctx context.Context, userID uuid.UUID, input dto.CreateExerciseSessionRequest) (*models.ExerciseSession, error) {
+	user, err := s.fetchUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	startedAt := time.Now().UTC()
+	if input.StartedAt != nil {
+		startedAt = input.StartedAt.UTC()
+	}
+
+	session := models.ExerciseSession{
+		UserID:          userID,
+		Type:            strings.TrimSpace(input.Type),
+		StartedAt:       startedAt,
+		DurationMinutes: input.DurationMinutes,
+		Intensity:       defaultString(input.Intensity, ExerciseIntensityModerate),
+		SweatLossMl:     input.SweatLossMl,
+		Timezone:        defaultString(input.Timezone, user.Timezone),
+		Notes:           input.Notes,
+	}
+	if err := validateExerciseSession(&session, user); err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&session).Error; err != nil {
+			return fmt.Errorf("create exercise session: %w", err)
+		}
+		return refreshGoalAdjustments(tx, user, session.DailyKey)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (s *ExerciseService) UpdateSession(ctx context.Context, userID, sessionID uuid.UUID, input dto.UpdateExerciseSessionRequest) (*models.ExerciseSession, error) {
+	user, err := s.fetchUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.fetchSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	previousDailyKey := session.DailyKey
+
+	if input.Type != nil {
+		session.Type = strings.TrimSpace(*input.Type)
+	}
+	if input.StartedAt != nil {
+		session.StartedAt = input.StartedAt.UTC()
+	}
+	if input.DurationMinutes != nil {
+		session.DurationMinutes = *input.DurationMinutes
+	}
+	if input.Intensity != nil {
+		session.Intensity = *input.Intensity
+	}
+	if input.SweatLossMl != nil {
+		session.SweatLossMl = input.SweatLossMl
+	}
+	if input.ClearSweatLoss {
+		session.SweatLossMl = nil
+	}
+	if input.Timezone != nil {
+		session.Timezone = defaultString(*input.Timezone, user.Timezone)
+	}
+	if input.Notes != nil {
+		session.Notes = input.Notes
+	}
+	if err := validateExerciseSession(session, user); err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(session).Error; err != nil {
+			return fmt.Errorf("update exercise session: %w", err)
+		}
+		keys := []string{session.DailyKey}
+		if previousDailyKey != session.DailyKey {
+			keys = append(keys, previousDailyKey)
+		}
+		return refreshGoalAdjustments(tx, user, keys...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (s *ExerciseService) DeleteSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	user, err := s.fetchUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	session, err := s.fetchSession(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.ExerciseSession{}, "id = ?", session.ID).Error; err != nil {
+			return fmt.Errorf("delete exercise session: %w", err)
+		}
+		return reapplyGoalAdjustments(tx, user, session.DailyKey)
+	})
+}
+
+// validateExerciseSession normalizes the session and derives its daily key from StartedAt.
+func validateExerciseSession(session *models.ExerciseSession, user *models.User) error {
+	session.Intensity = strings.ToLower(strings.TrimSpace(session.Intensity))
+	if _, ok := exerciseRateMlPerHour[session.Intensity]; !ok {
+		return ErrInvalidExerciseIntensity
+	}
+	if session.DurationMinutes <= 0 || session.DurationMinutes > maxExerciseMinutes {
+		return fmt.Errorf("durationMinutes must be between 1 and %d", maxExerciseMinutes)
+	}
+	if session.SweatLossMl != nil && *session.SweatLossMl < 0 {
+		return fmt.Errorf("sweatLossMl cannot be negative")
+	}
+
+	loc, err := utils.LoadLocation(session.Timezone)
+	if err != nil {
+		return err
+	}
+	session.Timezone = loc.String()
+	session.DailyKey = utils.DailyKey(session.StartedAt, loc, user.DayStartOffsetMinutes)
+	return nil
+}
+
+func (s *ExerciseService) fetchSession(ctx context.Context, userID, sessionID uuid.UUID) (*models.ExerciseSession, error) {
+	var session models.ExerciseSession
+	if err := s.db.WithContext(ctx).First(&session, "id = ? AND user_id = ?", sessionID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExerciseSessionNotFound
+		}
+		return nil, fmt.Errorf("fetch exercise session: %w", err)
+	}
+	return &session, nil
+}
+
+func (s *ExerciseService) fetchUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).First(&user, "id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, fmt.Errorf("fetch user: %w", err)
+	}
+	return &user, nil
+}
@@ -1,6 +1,62 @@
 package services
 
-import "strings"
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+)
+
+// Built-in goal strategy names.
+const (
+	GoalStrategyWeightBased = "weight_based"
+	GoalStrategyEFSA        = "efsa"
+	GoalStrategyIOM         = "iom"
+	GoalStrategyCustom      = "custom"
+)
+
+// minimumGoalLiters is the floor applied by the formula-based strategies.
+const minimumGoalLiters = 1.5
+
+// GoalProfile carries the profile attributes a GoalStrategy may read.
+type GoalProfile struct {
+	WeightKg         float64
+	Age              int
+	Gender           string
+	ActivityLevel    string
+	CustomGoalLiters *float64
+}
+
+// GoalComputation is a strategy's result. Components explain the goal line by line and sum to
+// Liters in milliliters; Inputs are the profile values the strategy actually used.
+type GoalComputation struct {
+	Liters     float64
+	Inputs     map[string]interface{}
+	Components []models.GoalAdjustment
+}
+
+// GoalStrategy turns a profile into a daily goal.
+type GoalStrategy interface {
+	Name() string
+	Compute(profile GoalProfile) (GoalComputation, error)
+}
+
+var goalStrategies = map[string]GoalStrategy{
+	GoalStrategyWeightBased: weightBasedStrategy{},
+	GoalStrategyEFSA:        adequateIntakeStrategy{name: GoalStrategyEFSA, table: efsaAdequateIntake, beverageShare: efsaBeverageShare},
+	GoalStrategyIOM:         adequateIntakeStrategy{name: GoalStrategyIOM, table: iomBeverageIntake, beverageShare: 1},
+	GoalStrategyCustom:      customStrategy{},
+}
+
+// LookupGoalStrategy returns the strategy registered under name.
+func LookupGoalStrategy(name string) (GoalStrategy, error) {
+	strategy, ok := goalStrategies[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("unsupported goal strategy: %s", name)
+	}
+	return strategy, nil
+}
 
 // CalculateDailyGoalLiters returns a personalized hydration goal in liters.
 // The baseline is weight (kg) * 0.033 with adjustments for gender and activity level.
@@ -29,13 +85,167 @@ func CalculateDailyGoalLiters(weightKg float64, activityLevel, gender string) fl
 		base += 0.1
 	}
 
-	if base < 1.5 {
-		base = 1.5
+	if base < minimumGoalLiters {
+		base = minimumGoalLiters
 	}
 
 	return roundTo(base, 2)
 }
 
+// weightBasedStrategy is the original CalculateDailyGoalLiters formula.
+type weightBasedStrategy struct{}
+
+func (weightBasedStrategy) Name() string { return GoalStrategyWeightBased }
+
+func (s weightBasedStrategy) Compute(profile GoalProfile) (GoalComputation, error) {
+	liters := CalculateDailyGoalLiters(profile.WeightKg, profile.ActivityLevel, profile.Gender)
+	withoutActivity := CalculateDailyGoalLiters(profile.WeightKg, "sedentary", profile.Gender)
+	activityMl := math.Round(math.Max(liters-withoutActivity, 0) * 1000)
+
+	components := []models.GoalAdjustment{
+		{Source: s.Name(), Reason: "base", AmountMl: math.Round(liters*1000) - activityMl, Inputs: map[string]interface{}{
+			"weightKg": profile.WeightKg,
+			"gender":   profile.Gender,
+		}},
+	}
+	if activityMl > 0 {
+		components = append(components, models.GoalAdjustment{
+			Source:   s.Name(),
+			Reason:   "activity",
+			AmountMl: activityMl,
+			Inputs:   map[string]interface{}{"activityLevel": profile.ActivityLevel},
+		})
+	}
+
+	return GoalComputation{
+		Liters: liters,
+		Inputs: map[string]interface{}{
+			"weightKg":      profile.WeightKg,
+			"activityLevel": profile.ActivityLevel,
+			"gender":        profile.Gender,
+		},
+		Components: components,
+	}, nil
+}
+
+// adequateIntakeBand is one row of an adequate-intake table: ages up to MaxAge (inclusive)
+// get the listed liters per day.
+type adequateIntakeBand struct {
+	MaxAge int
+	Male   float64
+	Female float64
+}
+
+// efsaAdequateIntake holds EFSA (2010) adequate intakes of total water, food included.
+var efsaAdequateIntake = []adequateIntakeBand{
+	{MaxAge: 3, Male: 1.3, Female: 1.3},
+	{MaxAge: 8, Male: 1.6, Female: 1.6},
+	{MaxAge: 13, Male: 2.1, Female: 1.9},
+	{MaxAge: 200, Male: 2.5, Female: 2.0},
+}
+
+// efsaBeverageShare is the part of EFSA's total water expected to come from drinks.
+const efsaBeverageShare = 0.8
+
+// iomBeverageIntake holds IOM (2004) adequate intakes of total beverages, drinking water included.
+var iomBeverageIntake = []adequateIntakeBand{
+	{MaxAge: 3, Male: 0.9, Female: 0.9},
+	{MaxAge: 8, Male: 1.2, Female: 1.2},
+	{MaxAge: 13, Male: 1.8, Female: 1.6},
+	{MaxAge: 18, Male: 2.6, Female: 1.8},
+	{MaxAge: 200, Male: 3.0, Female: 2.2},
+}
+
+// adequateIntakeStrategy looks the goal up in a reference table by age and sex.
+// An unknown age is treated as adult and an unspecified sex averages both columns.
+type adequateIntakeStrategy struct {
+	name          string
+	table         []adequateIntakeBand
+	beverageShare float64
+}
+
+func (s adequateIntakeStrategy) Name() string { return s.name }
+
+func (s adequateIntakeStrategy) Compute(profile GoalProfile) (GoalComputation, error) {
+	band := s.table[len(s.table)-1]
+	if profile.Age > 0 {
+		for _, candidate := range s.table {
+			if profile.Age <= candidate.MaxAge {
+				band = candidate
+				break
+			}
+		}
+	}
+
+	var liters float64
+	switch strings.ToLower(profile.Gender) {
+	case "male", "man":
+		liters = band.Male
+	case "female", "woman":
+		liters = band.Female
+	default:
+		liters = (band.Male + band.Female) / 2
+	}
+
+	liters = roundTo(liters*s.beverageShare, 2)
+	inputs := map[string]interface{}{
+		"age":    profile.Age,
+		"gender": profile.Gender,
+	}
+	return GoalComputation{
+		Liters: liters,
+		Inputs: inputs,
+		Components: []models.GoalAdjustment{
+			{Source: s.Name(), Reason: "adequate_intake", AmountMl: math.Round(liters * 1000), Inputs: inputs},
+		},
+	}, nil
+}
+
+// customStrategy uses the user's flat target as-is.
+type customStrategy struct{}
+
+func (customStrategy) Name() string { return GoalStrategyCustom }
+
+func (s customStrategy) Compute(profile GoalProfile) (GoalComputation, error) {
+	if profile.CustomGoalLiters == nil || *profile.CustomGoalLiters <= 0 {
+		return GoalComputation{}, fmt.Errorf("customGoalLiters is required for the custom goal strategy")
+	}
+	inputs := map[string]interface{}{
+		"customGoalLiters": *profile.CustomGoalLiters,
+	}
+	return GoalComputation{
+		Liters: *profile.CustomGoalLiters,
+		Inputs: inputs,
+		Components: []models.GoalAdjustment{
+			{Source: s.Name(), Reason: "custom_target", AmountMl: math.Round(*profile.CustomGoalLiters * 1000), Inputs: inputs},
+		},
+	}, nil
+}
+
+// profileGoalComponents explains defaultGoalMl(user) with the components of the user's
+// strategy. When the stored goal no longer matches the strategy (e.g. it predates strategies)
+// the whole amount is reported as a single base line.
+func profileGoalComponents(user *models.User) []models.GoalAdjustment {
+	goalMl := defaultGoalMl(user)
+	fallback := []models.GoalAdjustment{{Source: "profile", Reason: "base", AmountMl: goalMl}}
+
+	strategy, err := LookupGoalStrategy(defaultString(user.GoalStrategy, GoalStrategyWeightBased))
+	if err != nil {
+		return fallback
+	}
+	computation, err := strategy.Compute(GoalProfile{
+		WeightKg:         user.WeightKg,
+		Age:              user.Age,
+		Gender:           user.Gender,
+		ActivityLevel:    user.ActivityLevel,
+		CustomGoalLiters: user.CustomGoalLiters,
+	})
+	if err != nil || math.Abs(computation.Liters*1000-goalMl) > 0.5 {
+		return fallback
+	}
+	return computation.Components
+}
+
 func roundTo(value float64, decimals int) float64 {
 	pow := 1.0
 	for i := 0; i < decimals; i++ {
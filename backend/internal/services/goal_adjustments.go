@@ -0,0 +1,211 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/AD-Archer/archer-aqua/backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+// GoalAdjuster adds day-specific bonuses on top of a day's base goal. Adjusters are re-run
+// whenever the day's DailyGoal is written, so they must be deterministic for stored inputs.
+type GoalAdjuster interface {
+	Name() string
+	Adjust(tx *gorm.DB, user *models.User, date string, baseGoalMl float64) ([]models.GoalAdjustment, error)
+}
+
+var goalAdjusters = []GoalAdjuster{
+	weatherAdjuster{},
+	exerciseAdjuster{},
+}
+
+// applyGoalAdjustments recomputes every adjuster for the goal's day and sets GoalMl to the
+// base goal plus the resulting breakdown. Users in fluid restriction mode get no adjustments.
+func applyGoalAdjustments(tx *gorm.DB, user *models.User, goal *models.DailyGoal) error {
+	adjustments := make([]models.GoalAdjustment, 0)
+	for _, adjuster := range goalAdjusters {
+		if user.FluidRestrictionEnabled {
+			// A medical fluid limit must never be raised automatically.
+			break
+		}
+		found, err := adjuster.Adjust(tx, user, goal.Date, goal.BaseGoalMl)
+		if err != nil {
+			return fmt.Errorf("%s goal adjustment: %w", adjuster.Name(), err)
+		}
+		adjustments = append(adjustments, found...)
+	}
+
+	goal.Adjustments = adjustments
+	goal.GoalMl = goal.BaseGoalMl
+	for _, adjustment := range adjustments {
+		goal.GoalMl += adjustment.AmountMl
+	}
+	return nil
+}
+
+// dayRange returns the instants bounding a daily key for the user, in the timezone the user was
+// in on that day (see timezoneOnDate), along with that zone.
+func dayRange(tx *gorm.DB, user *models.User, date string) (time.Time, time.Time, *time.Location, error) {
+	loc, err := timezoneOnDate(tx, user, date)
+	if err != nil {
+		return time.Time{}, time.Time{}, nil, err
+	}
+	day, err := time.ParseInLocation(time.DateOnly, date, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, nil, fmt.Errorf("parse date: %w", err)
+	}
+	dayStart := time.Date(day.Year(), day.Month(), day.Day(), 0, user.DayStartOffsetMinutes, 0, 0, loc)
+	start, end := utils.DayBounds(dayStart, loc, user.DayStartOffsetMinutes)
+	return start, end, loc, nil
+}
+
+// Weather bonus rules, mirroring the client's weather multiplier but only ever adding water.
+// Heat uses the higher of the air temperature and the feels-like value, so humid heat counts.
+//   - above 30 °C: +30% of the base goal ("heat")
+//   - above 25 °C: +20% of the base goal ("heat")
+//   - humidity below 30%: +15% ("dry_air"); below 50%: +5%
+//
+// Amounts are rounded to 10 ml.
+const (
+	weatherHotCelsius     = 25.0
+	weatherVeryHotCelsius = 30.0
+)
+
+// weatherAdjuster uses the day's DailyWeather, the hottest reading fetched during the day.
+type weatherAdjuster struct{}
+
+func (weatherAdjuster) Name() string { return "weather" }
+
+func (a weatherAdjuster) Adjust(tx *gorm.DB, user *models.User, date string, baseGoalMl float64) ([]models.GoalAdjustment, error) {
+	if !user.WeatherAdjustmentsEnabled || baseGoalMl <= 0 {
+		return nil, nil
+	}
+
+	var readings []models.DailyWeather
+	if err := tx.Where("user_id = ? AND daily_key = ?", user.ID, date).
+		Limit(1).
+		Find(&readings).Error; err != nil {
+		return nil, fmt.Errorf("fetch daily weather: %w", err)
+	}
+	if len(readings) == 0 {
+		return nil, nil
+	}
+
+	hottest := readings[0]
+	heatIndex := math.Max(hottest.Temperature, hottest.FeelsLike)
+
+	heatShare := 0.0
+	switch {
+	case heatIndex > weatherVeryHotCelsius:
+		heatShare = 0.3
+	case heatIndex > weatherHotCelsius:
+		heatShare = 0.2
+	}
+
+	dryShare := 0.0
+	switch {
+	case hottest.Humidity <= 0:
+		// Missing humidity reading.
+	case hottest.Humidity < 30:
+		dryShare = 0.15
+	case hottest.Humidity < 50:
+		dryShare = 0.05
+	}
+
+	inputs := map[string]interface{}{
+		"temperatureC": hottest.Temperature,
+		"feelsLikeC":   hottest.FeelsLike,
+		"humidity":     hottest.Humidity,
+		"fetchedAt":    hottest.FetchedAt,
+	}
+
+	var adjustments []models.GoalAdjustment
+	if heatShare > 0 {
+		adjustments = append(adjustments, models.GoalAdjustment{
+			Source:   a.Name(),
+			Reason:   "heat",
+			AmountMl: roundToNearest(baseGoalMl*heatShare, 10),
+			Inputs:   inputs,
+		})
+	}
+	if dryShare > 0 {
+		adjustments = append(adjustments, models.GoalAdjustment{
+			Source:   a.Name(),
+			Reason:   "dry_air",
+			AmountMl: roundToNearest(baseGoalMl*dryShare, 10),
+			Inputs:   inputs,
+		})
+	}
+	return adjustments, nil
+}
+
+// exerciseRateMlPerHour estimates fluid lost per hour of exercise by intensity, in line with
+// the 0.3–0.8 L/h range commonly cited for recreational athletes. A measured sweat loss on the
+// session replaces the estimate, and so does the replacement recommended by the user's sweat
+// tests (sweatReplacementMlPerHour) when sweat rate goals are enabled.
+var exerciseRateMlPerHour = map[string]float64{
+	ExerciseIntensityLow:      300,
+	ExerciseIntensityModerate: 500,
+	ExerciseIntensityHigh:     750,
+}
+
+// exerciseAdjuster adds one line per exercise session that started on the day.
+type exerciseAdjuster struct{}
+
+func (exerciseAdjuster) Name() string { return "exercise" }
+
+func (a exerciseAdjuster) Adjust(tx *gorm.DB, user *models.User, date string, baseGoalMl float64) ([]models.GoalAdjustment, error) {
+	var sessions []models.ExerciseSession
+	if err := tx.Where("user_id = ? AND daily_key = ?", user.ID, date).
+		Order("started_at ASC").
+		Find(&sessions).Error; err != nil {
+		return nil, fmt.Errorf("fetch exercise sessions: %w", err)
+	}
+
+	var sweatTests []models.SweatTest
+	if user.SweatRateGoalsEnabled && len(sessions) > 0 {
+		var err error
+		if sweatTests, err = loadSweatTests(tx, user.ID, date); err != nil {
+			return nil, err
+		}
+	}
+
+	adjustments := make([]models.GoalAdjustment, 0, len(sessions))
+	for _, session := range sessions {
+		hours := float64(session.DurationMinutes) / 60
+		inputs := map[string]interface{}{
+			"sessionId":       session.ID,
+			"durationMinutes": session.DurationMinutes,
+			"intensity":       session.Intensity,
+			"sweatLossMl":     session.SweatLossMl,
+		}
+
+		amountMl := exerciseRateMlPerHour[session.Intensity] * hours
+		if rate, count := personalSweatRate(sweatTests, session.Type); count > 0 {
+			amountMl = sweatReplacementMlPerHour(rate) * hours
+			inputs["sweatRateLPerHour"] = rate
+			inputs["sweatTests"] = count
+		}
+		if session.SweatLossMl != nil {
+			amountMl = *session.SweatLossMl
+		}
+		if amountMl <= 0 {
+			continue
+		}
+
+		adjustments = append(adjustments, models.GoalAdjustment{
+			Source:   a.Name(),
+			Reason:   defaultString(session.Type, "exercise"),
+			AmountMl: roundToNearest(amountMl, 10),
+			Inputs:   inputs,
+		})
+	}
+	return adjustments, nil
+}
+
+func roundToNearest(value, step float64) float64 {
+	return math.Round(value/step) * step
+}
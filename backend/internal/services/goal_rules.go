@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/AD-Archer/archer-aqua/backend/internal/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrGoalRuleNotFound = errors.New("goal rule not found")
+
+// ListGoalRules returns all of the user's goal rules in precedence order.
+func (s *DailyGoalService) ListGoalRules(ctx context.Context, userID uuid.UUID) ([]models.GoalRule, error) {
+	var rules []models.GoalRule
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("priority DESC, updated_at DESC").
+		Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("list goal rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (s *DailyGoalService) CreateGoalRule(ctx context.Context, userID uuid.UUID, input dto.GoalRuleRequest) (*models.GoalRule, error) {
+	rule := models.GoalRule{UserID: userID, Enabled: true}
+	if err := applyGoalRuleRequest(&rule, input); err != nil {
+		return nil, err
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&rule).Error; err != nil {
+			return fmt.Errorf("create goal rule: %w", err)
+		}
+		return resnapshotUpcomingGoals(tx, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+// UpdateGoalRule replaces a rule with the request.
+func (s *DailyGoalService) UpdateGoalRule(ctx context.Context, userID, ruleID uuid.UUID, input dto.GoalRuleRequest) (*models.GoalRule, error) {
+	rule, err := s.fetchGoalRule(ctx, userID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyGoalRuleRequest(rule, input); err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(rule).Error; err != nil {
+			return fmt.Errorf("update goal rule: %w", err)
+		}
+		return resnapshotUpcomingGoals(tx, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *DailyGoalService) DeleteGoalRule(ctx context.Context, userID, ruleID uuid.UUID) error {
+	rule, err := s.fetchGoalRule(ctx, userID, ruleID)
+	if err != nil {
+		return err
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.GoalRule{}, "id = ?", rule.ID).Error; err != nil {
+			return fmt.Errorf("delete goal rule: %w", err)
+		}
+		return resnapshotUpcomingGoals(tx, userID)
+	})
+}
+
+func (s *DailyGoalService) fetchGoalRule(ctx context.Context, userID, ruleID uuid.UUID) (*models.GoalRule, error) {
+	var rule models.GoalRule
+	if err := s.db.WithContext(ctx).First(&rule, "id = ? AND user_id = ?", ruleID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGoalRuleNotFound
+		}
+		return nil, fmt.Errorf("fetch goal rule: %w", err)
+	}
+	return &rule, nil
+}
+
+// applyGoalRuleRequest validates the request and copies it onto rule.
+func applyGoalRuleRequest(rule *models.GoalRule, input dto.GoalRuleRequest) error {
+	if input.GoalMl <= 0 {
+		return fmt.Errorf("goalMl must be positive")
+	}
+
+	weekdays := 0
+	for _, name := range input.Weekdays {
+		name = strings.ToLower(strings.TrimSpace(name))
+		found := false
+		for day, weekday := range dto.WeekdayNames {
+			// Accept "mon" or "monday", but not prefixes like "monkey".
+			if name == weekday || name == strings.ToLower(time.Weekday(day).String()) {
+				weekdays |= 1 << day
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("invalid weekday: %s", name)
+		}
+	}
+
+	for _, date := range []*string{input.StartDate, input.EndDate} {
+		if date == nil {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, *date); err != nil {
+			return fmt.Errorf("invalid date format (expected YYYY-MM-DD): %s", *date)
+		}
+	}
+	if input.StartDate != nil && input.EndDate != nil && *input.StartDate > *input.EndDate {
+		return fmt.Errorf("startDate must not be after endDate")
+	}
+
+	rule.Name = strings.TrimSpace(input.Name)
+	rule.GoalMl = input.GoalMl
+	rule.Weekdays = weekdays
+	rule.StartDate = input.StartDate
+	rule.EndDate = input.EndDate
+	rule.Priority = input.Priority
+	if input.Enabled != nil {
+		rule.Enabled = *input.Enabled
+	}
+	return nil
+}
+
+// loadGoalRules returns the user's enabled rules in precedence order for matchGoalRule.
+func loadGoalRules(db *gorm.DB, userID uuid.UUID) ([]models.GoalRule, error) {
+	var rules []models.GoalRule
+	if err := db.Where("user_id = ? AND enabled = ?", userID, true).
+		Order("priority DESC, updated_at DESC").
+		Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("load goal rules: %w", err)
+	}
+	return rules, nil
+}
+
+// matchGoalRule returns the first rule in rules that applies to date, or nil.
+func matchGoalRule(rules []models.GoalRule, date string) *models.GoalRule {
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return nil
+	}
+
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Weekdays != 0 && rule.Weekdays&(1<<day.Weekday()) == 0 {
+			continue
+		}
+		if rule.StartDate != nil && date < *rule.StartDate {
+			continue
+		}
+		if rule.EndDate != nil && date > *rule.EndDate {
+			continue
+		}
+		return rule
+	}
+	return nil
+}
+
+// goalSchedule is what a day's base goal depends on besides the profile: the goal rules and
+// the weight history.
+type goalSchedule struct {
+	rules   []models.GoalRule
+	weights []models.WeightMeasurement
+}
+
+func loadGoalSchedule(db *gorm.DB, userID uuid.UUID) (*goalSchedule, error) {
+	rules, err := loadGoalRules(db, userID)
+	if err != nil {
+		return nil, err
+	}
+	weights, err := loadWeightHistory(db, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &goalSchedule{rules: rules, weights: weights}, nil
+}
+
+// scheduledBaseGoal is the base goal for date before adjustments: the matching rule's goal,
+// otherwise the profile goal for the weight in effect that day.
+func scheduledBaseGoal(user *models.User, schedule *goalSchedule, date string) (float64, []models.GoalAdjustment) {
+	rule := matchGoalRule(schedule.rules, date)
+	if rule == nil {
+		profile := profileOnDate(user, schedule.weights, date)
+		return defaultGoalMl(profile), profileGoalComponents(profile)
+	}
+
+	return rule.GoalMl, []models.GoalAdjustment{{
+		Source:   models.GoalRuleSource,
+		Reason:   defaultString(rule.Name, "goal rule"),
+		AmountMl: rule.GoalMl,
+		Inputs: map[string]interface{}{
+			"ruleId":   rule.ID,
+			"priority": rule.Priority,
+		},
+	}}
+}
+
+// resnapshotUpcomingGoals moves today's and future snapshot goals onto the current profile and
+// rules after either changed. Earlier days keep the goal they were snapshotted with.
+func resnapshotUpcomingGoals(tx *gorm.DB, userID uuid.UUID) error {
+	var user models.User
+	if err := tx.First(&user, "id = ?", userID).Error; err != nil {
+		return fmt.Errorf("fetch user: %w", err)
+	}
+
+	loc, err := utils.LoadLocation(user.Timezone)
+	if err != nil {
+		return err
+	}
+	today := utils.DailyKey(time.Now(), loc, user.DayStartOffsetMinutes)
+
+	var dates []string
+	if err := tx.Model(&models.DailyGoal{}).
+		Where("user_id = ? AND source = ? AND date >= ?", userID, models.DailyGoalSourceSnapshot, today).
+		Distinct().
+		Pluck("date", &dates).Error; err != nil {
+		return fmt.Errorf("fetch upcoming daily goals: %w", err)
+	}
+
+	for _, date := range dates {
+		if err := resnapshotDailyGoal(tx, &user, date); err != nil {
+			return err
+		}
+	}
+	return nil
+}
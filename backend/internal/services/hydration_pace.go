@@ -0,0 +1,80 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+)
+
+// Pace statuses returned on today's summary.
+const (
+	PaceStatusAhead   = "ahead"
+	PaceStatusOnTrack = "on_track"
+	PaceStatusBehind  = "behind"
+)
+
+// paceTolerance is the share of the daily goal within which the user counts as on track.
+const paceTolerance = 0.05
+
+// computePace spreads goalMl evenly across the user's wake window for the given day and
+// compares the expected intake at now with what was actually consumed.
+func computePace(user *models.User, dateKey string, loc *time.Location, now time.Time, goalMl, actualEffectiveMl float64) (*dto.HydrationPaceResponse, error) {
+	wakeAt, sleepAt, err := wakeWindow(user, dateKey, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedAt := func(t time.Time) float64 {
+		window := sleepAt.Sub(wakeAt)
+		if window <= 0 {
+			return goalMl
+		}
+		fraction := float64(t.Sub(wakeAt)) / float64(window)
+		return goalMl * math.Min(math.Max(fraction, 0), 1)
+	}
+
+	expected := expectedAt(now)
+	difference := actualEffectiveMl - expected
+
+	status := PaceStatusOnTrack
+	switch {
+	case difference > goalMl*paceTolerance:
+		status = PaceStatusAhead
+	case difference < -goalMl*paceTolerance:
+		status = PaceStatusBehind
+	}
+
+	return &dto.HydrationPaceResponse{
+		AsOf:                  now,
+		WakeAt:                wakeAt,
+		SleepAt:               sleepAt,
+		ExpectedEffectiveMl:   expected,
+		ActualEffectiveMl:     actualEffectiveMl,
+		DifferenceMl:          difference,
+		Status:                status,
+		RecommendedNextHourMl: math.Max(expectedAt(now.Add(time.Hour))-actualEffectiveMl, 0),
+	}, nil
+}
+
+// wakeWindow returns when the user wakes and goes to sleep within the given day.
+func wakeWindow(user *models.User, dateKey string, loc *time.Location) (time.Time, time.Time, error) {
+	date, err := time.ParseInLocation(time.DateOnly, dateKey, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	// Wall-clock construction keeps the window fixed across DST changes.
+	wakeMinutes := user.WakeTimeMinutes
+	if wakeMinutes < user.DayStartOffsetMinutes {
+		wakeMinutes += minutesPerDay
+	}
+	sleepMinutes := user.SleepTimeMinutes
+	for sleepMinutes <= wakeMinutes {
+		sleepMinutes += minutesPerDay
+	}
+	wakeAt := time.Date(date.Year(), date.Month(), date.Day(), 0, wakeMinutes, 0, 0, loc)
+	sleepAt := time.Date(date.Year(), date.Month(), date.Day(), 0, sleepMinutes, 0, 0, loc)
+	return wakeAt, sleepAt, nil
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultHydrationLogPageSize = 50
+	maxHydrationLogPageSize     = 500
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ListHydrationLogs returns a page of logs ordered from newest to oldest.
+// Pages are keyed on (consumed_at, id) so the cursor stays stable while new logs are written.
+func (s *HydrationService) ListHydrationLogs(ctx context.Context, userID uuid.UUID, query dto.HydrationLogQuery) (*dto.HydrationLogPageResponse, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultHydrationLogPageSize
+	}
+	if limit > maxHydrationLogPageSize {
+		limit = maxHydrationLogPageSize
+	}
+
+	db := s.db.WithContext(ctx).Where("user_id = ?", userID)
+
+	if query.From != "" {
+		db = db.Where("daily_key >= ?", query.From)
+	}
+	if query.To != "" {
+		db = db.Where("daily_key <= ?", query.To)
+	}
+	if query.DrinkID != nil {
+		db = db.Where("drink_id = ?", *query.DrinkID)
+	}
+	if source := strings.TrimSpace(query.Source); source != "" {
+		db = db.Where("source = ?", source)
+	}
+	if label := strings.TrimSpace(query.Label); label != "" {
+		db = db.Where("label ILIKE ?", "%"+escapeLike(label)+"%")
+	}
+	if query.MinVolumeMl != nil {
+		db = db.Where("volume_ml >= ?", *query.MinVolumeMl)
+	}
+	if query.MaxVolumeMl != nil {
+		db = db.Where("volume_ml <= ?", *query.MaxVolumeMl)
+	}
+
+	if query.Cursor != "" {
+		consumedAt, id, err := decodeLogCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where("(consumed_at, id) < (?, ?)", consumedAt, id)
+	}
+
+	var logs []models.HydrationLog
+	if err := db.
+		Order("consumed_at DESC, id DESC").
+		Limit(limit + 1).
+		Find(&logs).Error; err != nil {
+		return nil, fmt.Errorf("list hydration logs: %w", err)
+	}
+
+	var nextCursor *string
+	if len(logs) > limit {
+		logs = logs[:limit]
+		last := logs[len(logs)-1]
+		cursor := encodeLogCursor(last.ConsumedAt, last.ID)
+		nextCursor = &cursor
+	}
+
+	responses := make([]dto.HydrationLogResponse, 0, len(logs))
+	for _, logEntry := range logs {
+		responses = append(responses, dto.NewHydrationLogResponse(logEntry))
+	}
+
+	return &dto.HydrationLogPageResponse{
+		Logs:       responses,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+func encodeLogCursor(consumedAt time.Time, id uuid.UUID) string {
+	raw := consumedAt.UTC().Format(time.RFC3339Nano) + "|" + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeLogCursor(cursor string) (time.Time, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	consumedAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	return consumedAt, id, nil
+}
+
+func escapeLike(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(value)
+}
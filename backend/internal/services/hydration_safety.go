@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Safety warning windows and severities stored on SafetyWarning.
+const (
+	SafetyWindowHour = "hour"
+	SafetyWindowDay  = "day"
+
+	SafetySeverityCaution = "caution"
+	SafetySeverityDanger  = "danger"
+)
+
+// Intake thresholds per kilogram of body weight. Healthy kidneys clear roughly 0.7–1.0 L of
+// free water an hour in a 70 kg adult; drinking faster than that for long risks hyponatremia.
+//   - trailing hour: caution above 10 ml/kg, danger above 14 ml/kg (~0.7 / ~1.0 L at 70 kg)
+//   - trailing day: caution above 70 ml/kg, danger above 100 ml/kg (~4.9 / 7 L at 70 kg)
+//
+// Intake is the raw volume drunk, not the hydration-weighted amount.
+var safetyThresholdsMlPerKg = []struct {
+	window   string
+	span     time.Duration
+	caution  float64
+	danger   float64
+	describe string
+}{
+	{SafetyWindowHour, time.Hour, 10, 14, "the last hour"},
+	{SafetyWindowDay, 24 * time.Hour, 70, 100, "the last 24 hours"},
+}
+
+// Weight bounds for the thresholds. Missing weights use the reference adult; clearance does not
+// keep scaling with weight, so heavier users are capped.
+const (
+	safetyReferenceWeightKg = 70.0
+	safetyMinWeightKg       = 40.0
+	safetyMaxWeightKg       = 100.0
+)
+
+func safetyWeightKg(user *models.User) float64 {
+	if user.WeightKg <= 0 {
+		return safetyReferenceWeightKg
+	}
+	return math.Min(math.Max(user.WeightKg, safetyMinWeightKg), safetyMaxWeightKg)
+}
+
+// evaluateIntakeSafety checks the trailing intake windows ending at a newly stored log and
+// persists a warning for each window whose threshold is crossed. The log must already be
+// created in tx so that it counts towards its own windows.
+func evaluateIntakeSafety(tx *gorm.DB, user *models.User, logEntry *models.HydrationLog) ([]models.SafetyWarning, error) {
+	weightKg := safetyWeightKg(user)
+
+	warnings := make([]models.SafetyWarning, 0)
+	for _, threshold := range safetyThresholdsMlPerKg {
+		var intakeMl float64
+		if err := tx.Model(&models.HydrationLog{}).
+			Select("COALESCE(SUM(volume_ml), 0)").
+			Where("user_id = ? AND consumed_at > ? AND consumed_at <= ?", user.ID, logEntry.ConsumedAt.Add(-threshold.span), logEntry.ConsumedAt).
+			Scan(&intakeMl).Error; err != nil {
+			return nil, fmt.Errorf("sum %s intake: %w", threshold.window, err)
+		}
+
+		severity, limitMl := "", 0.0
+		switch {
+		case intakeMl > threshold.danger*weightKg:
+			severity, limitMl = SafetySeverityDanger, threshold.danger*weightKg
+		case intakeMl > threshold.caution*weightKg:
+			severity, limitMl = SafetySeverityCaution, threshold.caution*weightKg
+		default:
+			continue
+		}
+
+		warnings = append(warnings, models.SafetyWarning{
+			UserID:         user.ID,
+			HydrationLogID: &logEntry.ID,
+			DailyKey:       logEntry.DailyKey,
+			TriggeredAt:    logEntry.ConsumedAt,
+			Window:         threshold.window,
+			Severity:       severity,
+			IntakeMl:       math.Round(intakeMl),
+			ThresholdMl:    math.Round(limitMl),
+			WeightKg:       weightKg,
+			Message: fmt.Sprintf("%.0f ml in %s is above the %s threshold of %.0f ml for %.0f kg",
+				intakeMl, threshold.describe, severity, limitMl, weightKg),
+		})
+	}
+
+	if len(warnings) > 0 {
+		if err := tx.Create(&warnings).Error; err != nil {
+			return nil, fmt.Errorf("store safety warnings: %w", err)
+		}
+	}
+	return warnings, nil
+}
+
+// ListSafetyWarnings returns warnings between two inclusive daily keys, newest first; empty
+// bounds are open.
+func (s *HydrationService) ListSafetyWarnings(ctx context.Context, userID uuid.UUID, from, to string) ([]models.SafetyWarning, error) {
+	query := s.db.WithContext(ctx).Where("user_id = ?", userID)
+	if from != "" {
+		query = query.Where("daily_key >= ?", from)
+	}
+	if to != "" {
+		query = query.Where("daily_key <= ?", to)
+	}
+
+	var warnings []models.SafetyWarning
+	if err := query.Order("triggered_at DESC").Find(&warnings).Error; err != nil {
+		return nil, fmt.Errorf("list safety warnings: %w", err)
+	}
+	return warnings, nil
+}
@@ -13,39 +13,216 @@ import (
 	"github.com/google/uuid"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // HydrationService manages hydration logs, summaries, and streaks.
 type HydrationService struct {
 	db           *gorm.DB
 	dailyGoalSvc *DailyGoalService
+	streakSvc    *StreakService
 }
 
-func NewHydrationService(db *gorm.DB, dailyGoalSvc *DailyGoalService) *HydrationService {
+func NewHydrationService(db *gorm.DB, dailyGoalSvc *DailyGoalService, streakSvc *StreakService) *HydrationService {
 	return &HydrationService{
 		db:           db,
 		dailyGoalSvc: dailyGoalSvc,
+		streakSvc:    streakSvc,
 	}
 }
 
 var ErrHydrationLogNotFound = errors.New("hydration log not found")
 
+// errIdempotentReplay aborts a write whose idempotency key was stored concurrently.
+var errIdempotentReplay = errors.New("idempotency key already stored")
+
+const maxHydrationLogBatchSize = 500
+
+// maxIdempotencyKeyLength matches the size of HydrationLog.IdempotencyKey.
+const maxIdempotencyKeyLength = 128
+
+// LogHydration records a single drink. When the request carries an idempotency key that
+// was already stored for the user, the existing log is returned instead of a duplicate.
+// New logs carry any intake safety warnings they raised.
 func (s *HydrationService) LogHydration(ctx context.Context, userID uuid.UUID, input dto.LogHydrationRequest) (*models.HydrationLog, error) {
 	user, err := s.fetchUser(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	volumeMl, err := utils.ConvertVolumeToMl(input.Volume.Value, input.Volume.Unit)
+	key := normalizeIdempotencyKey(input.IdempotencyKey)
+	if existing, err := s.findLogByIdempotencyKey(ctx, s.db, userID, key); err != nil || existing != nil {
+		return existing, err
+	}
+
+	logEntry, err := s.buildHydrationLog(ctx, user, input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := createHydrationLog(tx, logEntry); err != nil {
+			return err
+		}
+		warnings, err := evaluateIntakeSafety(tx, user, logEntry)
+		if err != nil {
+			return err
+		}
+		logEntry.SafetyWarnings = warnings
+		if logEntry.CaffeineWarning, err = caffeineBedtimeWarning(tx, user, logEntry); err != nil {
+			return err
+		}
+		if err := drainContainer(tx, logEntry); err != nil {
+			return err
+		}
+		if err := refreshDailyRollups(tx, userID, logEntry.DailyKey); err != nil {
+			return err
+		}
+		return recordSyncChange(tx, userID, models.SyncEntityHydrationLog, logEntry.ID, models.SyncOperationUpsert)
+	})
+	if errors.Is(err, errIdempotentReplay) {
+		// A concurrent replay with the same key won the insert.
+		existing, err := s.findLogByIdempotencyKey(ctx, s.db, userID, key)
+		if err == nil && existing == nil {
+			err = fmt.Errorf("log hydration: %w", errIdempotentReplay)
+		}
+		return existing, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return logEntry, nil
+}
+
+// createHydrationLog inserts a new log. A concurrent insert with the same idempotency key
+// makes it return errIdempotentReplay instead of a unique violation.
+func createHydrationLog(tx *gorm.DB, logEntry *models.HydrationLog) error {
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(logEntry)
+	if result.Error != nil {
+		return fmt.Errorf("log hydration: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errIdempotentReplay
+	}
+	return nil
+}
+
+// LogHydrationBatch stores many logs in a single transaction, typically replayed from an
+// offline client queue. Items that fail validation or cannot be stored are reported
+// individually without aborting the batch; items whose idempotency key already exists
+// return the stored log.
+func (s *HydrationService) LogHydrationBatch(ctx context.Context, userID uuid.UUID, inputs []dto.LogHydrationRequest) ([]dto.HydrationLogBatchResult, error) {
+	if len(inputs) > maxHydrationLogBatchSize {
+		return nil, fmt.Errorf("batch exceeds %d logs", maxHydrationLogBatchSize)
+	}
+
+	user, err := s.fetchUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dto.HydrationLogBatchResult, 0, len(inputs))
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		touchedKeys := make([]string, 0, len(inputs))
+		for i, input := range inputs {
+			key := normalizeIdempotencyKey(input.IdempotencyKey)
+			result := dto.HydrationLogBatchResult{Index: i, IdempotencyKey: key}
+
+			existing, err := s.findLogByIdempotencyKey(ctx, tx, userID, key)
+			if err != nil {
+				return err
+			}
+			if existing != nil {
+				response := dto.NewHydrationLogResponse(*existing)
+				result.Status = dto.BatchStatusExisting
+				result.Log = &response
+				results = append(results, result)
+				continue
+			}
+
+			logEntry, err := s.buildHydrationLog(ctx, user, input)
+			if err != nil {
+				result.Status = dto.BatchStatusFailed
+				result.Error = err.Error()
+				results = append(results, result)
+				continue
+			}
+
+			// Each item writes under its own savepoint so a failing insert only fails that item.
+			err = tx.Transaction(func(itemTx *gorm.DB) error {
+				if err := createHydrationLog(itemTx, logEntry); err != nil {
+					return err
+				}
+				if err := recordSyncChange(itemTx, userID, models.SyncEntityHydrationLog, logEntry.ID, models.SyncOperationUpsert); err != nil {
+					return err
+				}
+				warnings, err := evaluateIntakeSafety(itemTx, user, logEntry)
+				if err != nil {
+					return err
+				}
+				logEntry.SafetyWarnings = warnings
+				if logEntry.CaffeineWarning, err = caffeineBedtimeWarning(itemTx, user, logEntry); err != nil {
+					return err
+				}
+				return drainContainer(itemTx, logEntry)
+			})
+			if errors.Is(err, errIdempotentReplay) {
+				if existing, err = s.findLogByIdempotencyKey(ctx, tx, userID, key); err != nil {
+					return err
+				}
+				if existing == nil {
+					return fmt.Errorf("log hydration %d: %w", i, errIdempotentReplay)
+				}
+				response := dto.NewHydrationLogResponse(*existing)
+				result.Status = dto.BatchStatusExisting
+				result.Log = &response
+				results = append(results, result)
+				continue
+			}
+			if err != nil {
+				result.Status = dto.BatchStatusFailed
+				result.Error = err.Error()
+				results = append(results, result)
+				continue
+			}
+			touchedKeys = append(touchedKeys, logEntry.DailyKey)
+
+			response := dto.NewHydrationLogResponse(*logEntry)
+			result.Status = dto.BatchStatusCreated
+			result.Log = &response
+			results = append(results, result)
+		}
+		return refreshDailyRollups(tx, userID, touchedKeys...)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	return results, nil
+}
+
+// buildHydrationLog validates a log request and derives every stored field without persisting it.
+// Food sent with a mass is logged as the water it contains, using the request's or the food's
+// water content ratio.
+func (s *HydrationService) buildHydrationLog(ctx context.Context, user *models.User, input dto.LogHydrationRequest) (*models.HydrationLog, error) {
 	hydrationMultiplier := 1.0
 
+	var container *models.Container
+	if input.ContainerID != nil {
+		c, err := fetchActiveContainer(s.db.WithContext(ctx), user.ID, *input.ContainerID)
+		if err != nil {
+			return nil, err
+		}
+		container = c
+		if input.DrinkID == nil {
+			input.DrinkID = c.DefaultDrinkID
+		}
+	}
+
 	var drink *models.Drink
 	if input.DrinkID != nil {
-		d, err := s.fetchDrink(ctx, userID, *input.DrinkID)
+		d, err := s.fetchDrink(ctx, user.ID, *input.DrinkID)
 		if err != nil {
 			return nil, err
 		}
@@ -60,6 +237,32 @@ func (s *HydrationService) LogHydration(ctx context.Context, userID uuid.UUID, i
 		hydrationMultiplier = *input.HydrationMultiplier
 	}
 
+	var volumeMl float64
+	var massG, waterContentRatio *float64
+	unitKey, unit := "volumeUnit", input.Volume.Unit
+	if input.Mass != nil {
+		grams, err := utils.ConvertMassToGrams(input.Mass.Value, input.Mass.Unit)
+		if err != nil {
+			return nil, err
+		}
+		waterContentRatio = input.WaterContentRatio
+		if waterContentRatio == nil && drink != nil && drink.WaterContentRatio > 0 {
+			ratio := drink.WaterContentRatio
+			waterContentRatio = &ratio
+		}
+		if volumeMl, err = foodWaterMl(grams, waterContentRatio); err != nil {
+			return nil, err
+		}
+		massG = &grams
+		unitKey, unit = "massUnit", input.Mass.Unit
+	} else {
+		converted, err := utils.ConvertVolumeToMl(input.Volume.Value, input.Volume.Unit)
+		if err != nil {
+			return nil, err
+		}
+		volumeMl = converted
+	}
+
 	consumedAt := input.ConsumedAt
 	if consumedAt.IsZero() {
 		consumedAt = time.Now().UTC()
@@ -75,42 +278,203 @@ func (s *HydrationService) LogHydration(ctx context.Context, userID uuid.UUID, i
 		return nil, err
 	}
 
-	consumedLocal := consumedAt.In(loc)
-	dailyKey := utils.DailyKey(consumedAt, loc)
-
-	effectiveMl := volumeMl * hydrationMultiplier
+	idempotencyKey := normalizeIdempotencyKey(input.IdempotencyKey)
+	if idempotencyKey != nil && len(*idempotencyKey) > maxIdempotencyKeyLength {
+		return nil, fmt.Errorf("idempotencyKey must be at most %d characters", maxIdempotencyKeyLength)
+	}
 
 	logEntry := models.HydrationLog{
-		UserID:              user.ID,
-		DrinkID:             nil,
-		Label:               strings.TrimSpace(input.Label),
-		VolumeMl:            volumeMl,
-		HydrationMultiplier: hydrationMultiplier,
-		EffectiveMl:         effectiveMl,
-		ConsumedAt:          consumedAt.UTC(),
-		ConsumedAtLocal:     consumedLocal,
-		Timezone:            loc.String(),
-		DailyKey:            dailyKey,
-		Source:              defaultString(input.Source, "manual"),
-		Notes:               input.Notes,
-	}
+		UserID:            user.ID,
+		DrinkID:           nil,
+		Label:             strings.TrimSpace(input.Label),
+		Source:            defaultString(input.Source, "manual"),
+		Notes:             input.Notes,
+		IdempotencyKey:    idempotencyKey,
+		MassG:             massG,
+		WaterContentRatio: waterContentRatio,
+	}
+	applyConsumption(&logEntry, volumeMl, hydrationMultiplier, consumedAt, loc, user.DayStartOffsetMinutes)
+	applyDrinkNutrients(&logEntry, drink)
 
 	if drink != nil {
 		logEntry.DrinkID = &drink.ID
 	}
+	if container != nil {
+		logEntry.ContainerID = &container.ID
+	}
 
 	logEntry.Metadata = datatypes.JSONMap{
-		"volumeUnit":       input.Volume.Unit,
+		unitKey:            unit,
 		"createdFromDrink": drink != nil,
 	}
 
-	if err := s.db.WithContext(ctx).Create(&logEntry).Error; err != nil {
-		return nil, fmt.Errorf("log hydration: %w", err)
+	return &logEntry, nil
+}
+
+func (s *HydrationService) findLogByIdempotencyKey(ctx context.Context, db *gorm.DB, userID uuid.UUID, key *string) (*models.HydrationLog, error) {
+	if key == nil {
+		return nil, nil
 	}
 
+	// Trashed logs still own their key so a late replay cannot resurrect a deleted drink.
+	var logEntry models.HydrationLog
+	if err := db.WithContext(ctx).Unscoped().First(&logEntry, "user_id = ? AND idempotency_key = ?", userID, *key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("fetch hydration log by idempotency key: %w", err)
+	}
 	return &logEntry, nil
 }
 
+func normalizeIdempotencyKey(key *string) *string {
+	if key == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*key)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+// UpdateHydrationLog applies partial changes to an existing log and recomputes
+// the derived volume and day fields exactly as LogHydration would.
+func (s *HydrationService) UpdateHydrationLog(ctx context.Context, userID, logID uuid.UUID, input dto.UpdateHydrationLogRequest) (*models.HydrationLog, error) {
+	user, err := s.fetchUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	logEntry, err := s.fetchHydrationLog(ctx, userID, logID)
+	if err != nil {
+		return nil, err
+	}
+
+	if logEntry.Metadata == nil {
+		logEntry.Metadata = datatypes.JSONMap{}
+	}
+	previousDailyKey := logEntry.DailyKey
+	previous := *logEntry
+
+	volumeMl := logEntry.VolumeMl
+	if input.Volume != nil {
+		converted, err := utils.ConvertVolumeToMl(input.Volume.Value, input.Volume.Unit)
+		if err != nil {
+			return nil, err
+		}
+		volumeMl = converted
+		logEntry.Metadata["volumeUnit"] = input.Volume.Unit
+		// An explicit volume turns a food entry back into a drink.
+		logEntry.MassG, logEntry.WaterContentRatio = nil, nil
+		delete(logEntry.Metadata, "massUnit")
+	}
+
+	hydrationMultiplier := logEntry.HydrationMultiplier
+	var drink *models.Drink
+	if input.DrinkID != nil {
+		drink, err = s.fetchDrink(ctx, userID, *input.DrinkID)
+		if err != nil {
+			return nil, err
+		}
+		logEntry.DrinkID = &drink.ID
+		logEntry.Metadata["createdFromDrink"] = true
+		hydrationMultiplier = drink.HydrationMultiplier
+		if input.Label == nil {
+			logEntry.Label = drink.Name
+		}
+	}
+
+	if input.HydrationMultiplier != nil && *input.HydrationMultiplier > 0 {
+		hydrationMultiplier = *input.HydrationMultiplier
+	}
+
+	if input.ContainerID != nil {
+		container, err := fetchActiveContainer(s.db.WithContext(ctx), userID, *input.ContainerID)
+		if err != nil {
+			return nil, err
+		}
+		logEntry.ContainerID = &container.ID
+	}
+	if input.ClearContainer {
+		logEntry.ContainerID = nil
+	}
+
+	if input.Mass != nil {
+		grams, err := utils.ConvertMassToGrams(input.Mass.Value, input.Mass.Unit)
+		if err != nil {
+			return nil, err
+		}
+		logEntry.MassG = &grams
+		logEntry.Metadata["massUnit"] = input.Mass.Unit
+		delete(logEntry.Metadata, "volumeUnit")
+	}
+	if logEntry.MassG != nil {
+		if drink != nil && drink.WaterContentRatio > 0 {
+			ratio := drink.WaterContentRatio
+			logEntry.WaterContentRatio = &ratio
+		}
+		if input.WaterContentRatio != nil {
+			logEntry.WaterContentRatio = input.WaterContentRatio
+		}
+		if volumeMl, err = foodWaterMl(*logEntry.MassG, logEntry.WaterContentRatio); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Label != nil {
+		logEntry.Label = strings.TrimSpace(*input.Label)
+	}
+
+	consumedAt := logEntry.ConsumedAt
+	if input.ConsumedAt != nil && !input.ConsumedAt.IsZero() {
+		consumedAt = *input.ConsumedAt
+	}
+
+	tz := logEntry.Timezone
+	if input.Timezone != nil && strings.TrimSpace(*input.Timezone) != "" {
+		tz = *input.Timezone
+	}
+
+	loc, err := utils.LoadLocation(tz)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Notes != nil {
+		if strings.TrimSpace(*input.Notes) == "" {
+			logEntry.Notes = nil
+		} else {
+			logEntry.Notes = input.Notes
+		}
+	}
+
+	applyConsumption(logEntry, volumeMl, hydrationMultiplier, consumedAt, loc, user.DayStartOffsetMinutes)
+	if drink != nil {
+		applyDrinkNutrients(logEntry, drink)
+	} else {
+		scaleNutrients(logEntry, nutrientBasis(&previous))
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(logEntry).Error; err != nil {
+			return fmt.Errorf("update hydration log: %w", err)
+		}
+		if err := updateContainerFills(tx, &previous, logEntry); err != nil {
+			return err
+		}
+		if err := refreshDailyRollups(tx, userID, previousDailyKey, logEntry.DailyKey); err != nil {
+			return err
+		}
+		return recordSyncChange(tx, userID, models.SyncEntityHydrationLog, logEntry.ID, models.SyncOperationUpsert)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return logEntry, nil
+}
+
 func (s *HydrationService) DailySummary(ctx context.Context, userID uuid.UUID, date time.Time, timezone string) (*dto.DailySummaryResponse, error) {
 	user, err := s.fetchUser(ctx, userID)
 	if err != nil {
@@ -142,186 +506,173 @@ func (s *HydrationService) DailySummary(ctx context.Context, userID uuid.UUID, d
 		return nil, fmt.Errorf("fetch logs: %w", err)
 	}
 
-	totalVolume := 0.0
-	totalEffective := 0.0
 	responses := make([]dto.HydrationLogResponse, 0, len(logs))
 	for _, logEntry := range logs {
-		totalVolume += logEntry.VolumeMl
-		totalEffective += logEntry.EffectiveMl
 		responses = append(responses, dto.NewHydrationLogResponse(logEntry))
 	}
 
-	goalMl, err := s.dailyGoalSvc.GetDailyGoal(ctx, userID, dateKey)
+	var sessions []models.ExerciseSession
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ? AND daily_key = ?", userID, dateKey).
+		Order("started_at ASC").
+		Find(&sessions).Error; err != nil {
+		return nil, fmt.Errorf("fetch exercise sessions: %w", err)
+	}
+
+	exercise := make([]dto.ExerciseSessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		exercise = append(exercise, dto.NewExerciseSessionResponse(session))
+	}
+
+	containers, err := listContainers(s.db.WithContext(ctx), userID, false)
 	if err != nil {
-		return nil, fmt.Errorf("get daily goal: %w", err)
+		return nil, err
+	}
+	containerResponses := make([]dto.ContainerResponse, 0, len(containers))
+	for _, container := range containers {
+		containerResponses = append(containerResponses, dto.NewContainerResponse(container))
 	}
 
-	progress := 0.0
-	if goalMl > 0 {
-		progress = (totalEffective / goalMl) * 100
+	totals, err := s.aggregateDailyTotals(ctx, userID, dateKey, dateKey)
+	if err != nil {
+		return nil, err
 	}
+	totalVolume := totals[dateKey].TotalVolumeMl
+	totalEffective := totals[dateKey].TotalEffectiveMl
+	nutrients := totals[dateKey].nutrients()
 
-	status := "not_started"
-	if totalEffective >= goalMl {
-		status = "completed"
-	} else if totalEffective > 0 {
-		status = "in_progress"
+	goal, err := s.dailyGoalSvc.GetDailyGoalDetail(ctx, userID, dateKey)
+	if err != nil {
+		return nil, fmt.Errorf("get daily goal: %w", err)
 	}
+	goalMl := goal.GoalMl
 
-	return &dto.DailySummaryResponse{
+	summary := &dto.DailySummaryResponse{
 		Date:               localDate.Format(time.DateOnly),
 		Timezone:           loc.String(),
 		TotalVolumeMl:      totalVolume,
 		TotalEffectiveMl:   totalEffective,
+		FromFoodMl:         totals[dateKey].TotalFoodMl,
 		GoalVolumeMl:       goalMl,
-		ProgressPercentage: progress,
-		Status:             status,
+		ProgressPercentage: progressPercentage(totalEffective, goalMl),
+		Status:             dayStatus(user, totalEffective, goalMl),
+		GoalBreakdown:      dto.NewGoalBreakdown(*goal),
 		Logs:               responses,
-	}, nil
-}
-
-func (s *HydrationService) WeeklyStats(ctx context.Context, userID uuid.UUID, timezone string, days int) (*dto.HydrationStatsResponse, error) {
-	if days <= 0 {
-		days = 7
+		Exercise:           exercise,
+		Containers:         containerResponses,
+		Nutrients:          nutrients,
+		NutrientWarnings:   nutrientWarnings(user, dateKey, nutrients),
 	}
 
-	user, err := s.fetchUser(ctx, userID)
-	if err != nil {
-		return nil, err
+	// Pace nudges towards drinking more, which doesn't apply to a fluid limit.
+	now := time.Now()
+	if !user.FluidRestrictionEnabled && dateKey == utils.DailyKey(now, loc, user.DayStartOffsetMinutes) {
+		pace, err := computePace(user, dateKey, loc, now, goalMl, totalEffective)
+		if err != nil {
+			return nil, fmt.Errorf("compute pace: %w", err)
+		}
+		summary.Pace = pace
 	}
 
-	tz := user.Timezone
-	if strings.TrimSpace(timezone) != "" {
-		tz = timezone
-	}
+	return summary, nil
+}
 
-	loc, err := utils.LoadLocation(tz)
+func (s *HydrationService) DeleteHydrationLog(ctx context.Context, userID, logID uuid.UUID) error {
+	logEntry, err := s.fetchHydrationLog(ctx, userID, logID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	now := time.Now().In(loc)
-	startRange := now.AddDate(0, 0, -days+1)
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.HydrationLog{}, "id = ?", logID).Error; err != nil {
+			return fmt.Errorf("delete hydration log: %w", err)
+		}
+		if err := refillContainer(tx, logEntry); err != nil {
+			return err
+		}
+		if err := refreshDailyRollups(tx, userID, logEntry.DailyKey); err != nil {
+			return err
+		}
+		return recordSyncChange(tx, userID, models.SyncEntityHydrationLog, logID, models.SyncOperationDelete)
+	})
+}
 
+// ListDeletedHydrationLogs returns logs currently in the trash, most recently deleted first.
+func (s *HydrationService) ListDeletedHydrationLogs(ctx context.Context, userID uuid.UUID) ([]models.HydrationLog, error) {
 	var logs []models.HydrationLog
-	if err := s.db.WithContext(ctx).
-		Where("user_id = ? AND consumed_at >= ?", userID, startRange.Add(-24*time.Hour).UTC()).
-		Order("consumed_at ASC").
+	if err := s.db.WithContext(ctx).Unscoped().
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at DESC").
 		Find(&logs).Error; err != nil {
-		return nil, fmt.Errorf("fetch logs: %w", err)
+		return nil, fmt.Errorf("list deleted hydration logs: %w", err)
 	}
+	return logs, nil
+}
 
-	summaries := make(map[string]*dto.DailySummaryResponse)
-	totalVolume := 0.0
-	totalEffective := 0.0
-
-	// Get daily goals for the date range
-	startDateKey := startRange.Format("2006-01-02")
-	endDateKey := now.Format("2006-01-02")
-	dailyGoals, err := s.dailyGoalSvc.GetDailyGoals(ctx, userID, startDateKey, endDateKey)
-	if err != nil {
-		return nil, fmt.Errorf("get daily goals: %w", err)
+// RestoreHydrationLog moves a trashed log back into the user's history.
+func (s *HydrationService) RestoreHydrationLog(ctx context.Context, userID, logID uuid.UUID) (*models.HydrationLog, error) {
+	var logEntry models.HydrationLog
+	if err := s.db.WithContext(ctx).Unscoped().
+		First(&logEntry, "id = ? AND user_id = ? AND deleted_at IS NOT NULL", logID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrHydrationLogNotFound
+		}
+		return nil, fmt.Errorf("fetch deleted hydration log: %w", err)
 	}
 
-	for _, logEntry := range logs {
-		key := utils.DailyKey(logEntry.ConsumedAtLocal, loc)
-		summary, exists := summaries[key]
-		if !exists {
-			goalMl, exists := dailyGoals[key]
-			if !exists {
-				// Use default goal if no specific goal set
-				goalMl = user.DailyGoalLiters * 1000
-				if goalMl == 0 {
-					goalMl = 2000
-				}
-			}
-			summary = &dto.DailySummaryResponse{
-				Date:         key,
-				Timezone:     loc.String(),
-				GoalVolumeMl: goalMl,
-			}
-			summaries[key] = summary
-		}
-		summary.TotalVolumeMl += logEntry.VolumeMl
-		summary.TotalEffectiveMl += logEntry.EffectiveMl
-		summary.Logs = append(summary.Logs, dto.NewHydrationLogResponse(logEntry))
-		totalVolume += logEntry.VolumeMl
-		totalEffective += logEntry.EffectiveMl
-	}
-
-	ordered := make([]dto.DailySummaryResponse, 0, len(summaries))
-	streak := 0
-	bestStreak := 0
-
-	for i := 0; i < days; i++ {
-		date := startRange.AddDate(0, 0, i)
-		key := date.Format("2006-01-02")
-		summary, exists := summaries[key]
-		if !exists {
-			goalMl, exists := dailyGoals[key]
-			if !exists {
-				// Use default goal if no specific goal set
-				goalMl = user.DailyGoalLiters * 1000
-				if goalMl == 0 {
-					goalMl = 2000
-				}
-			}
-			summary = &dto.DailySummaryResponse{
-				Date:         key,
-				Timezone:     loc.String(),
-				GoalVolumeMl: goalMl,
-				Logs:         []dto.HydrationLogResponse{},
-			}
-			summaries[key] = summary
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&logEntry).Update("deleted_at", nil).Error; err != nil {
+			return fmt.Errorf("restore hydration log: %w", err)
 		}
-
-		summary.ProgressPercentage = 0
-		if summary.GoalVolumeMl > 0 {
-			summary.ProgressPercentage = (summary.TotalEffectiveMl / summary.GoalVolumeMl) * 100
+		if err := drainContainer(tx, &logEntry); err != nil {
+			return err
 		}
-
-		if summary.TotalEffectiveMl >= summary.GoalVolumeMl {
-			summary.Status = "completed"
-			streak++
-			if streak > bestStreak {
-				bestStreak = streak
-			}
-		} else if summary.TotalEffectiveMl > 0 {
-			summary.Status = "in_progress"
-			streak = 0
-		} else {
-			summary.Status = "not_started"
-			streak = 0
+		if err := refreshDailyRollups(tx, userID, logEntry.DailyKey); err != nil {
+			return err
 		}
-
-		ordered = append(ordered, *summary)
+		return recordSyncChange(tx, userID, models.SyncEntityHydrationLog, logEntry.ID, models.SyncOperationUpsert)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return &dto.HydrationStatsResponse{
-		UserID:           user.ID,
-		Timezone:         loc.String(),
-		DailySummaries:   ordered,
-		StreakCount:      streak,
-		BestStreak:       bestStreak,
-		TotalVolumeMl:    totalVolume,
-		TotalEffectiveMl: totalEffective,
-	}, nil
+	logEntry.DeletedAt = gorm.DeletedAt{}
+	return &logEntry, nil
 }
 
-func (s *HydrationService) DeleteHydrationLog(ctx context.Context, userID, logID uuid.UUID) error {
+// PurgeDeletedHydrationLogs permanently removes logs that have been in the trash since before cutoff.
+func (s *HydrationService) PurgeDeletedHydrationLogs(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Delete(&models.HydrationLog{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("purge deleted hydration logs: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (s *HydrationService) fetchHydrationLog(ctx context.Context, userID, logID uuid.UUID) (*models.HydrationLog, error) {
 	var logEntry models.HydrationLog
 	if err := s.db.WithContext(ctx).First(&logEntry, "id = ? AND user_id = ?", logID, userID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ErrHydrationLogNotFound
+			return nil, ErrHydrationLogNotFound
 		}
-		return fmt.Errorf("fetch hydration log: %w", err)
-	}
-
-	if err := s.db.WithContext(ctx).Delete(&models.HydrationLog{}, "id = ?", logID).Error; err != nil {
-		return fmt.Errorf("delete hydration log: %w", err)
+		return nil, fmt.Errorf("fetch hydration log: %w", err)
 	}
+	return &logEntry, nil
+}
 
-	return nil
+// applyConsumption sets the volume, timing, and daily key fields of a log so that
+// creation and edits derive EffectiveMl, ConsumedAtLocal, and DailyKey identically.
+// dayStartMinutes is the user's configured day boundary.
+func applyConsumption(logEntry *models.HydrationLog, volumeMl, hydrationMultiplier float64, consumedAt time.Time, loc *time.Location, dayStartMinutes int) {
+	logEntry.VolumeMl = volumeMl
+	logEntry.HydrationMultiplier = hydrationMultiplier
+	logEntry.EffectiveMl = volumeMl * hydrationMultiplier
+	logEntry.ConsumedAt = consumedAt.UTC()
+	logEntry.ConsumedAtLocal = consumedAt.In(loc)
+	logEntry.Timezone = loc.String()
+	logEntry.DailyKey = utils.DailyKey(consumedAt, loc, dayStartMinutes)
 }
 
 func (s *HydrationService) fetchUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/AD-Archer/archer-aqua/backend/internal/utils"
+	"github.com/google/uuid"
+)
+
+// Stats bucket sizes accepted by WeeklyStats.
+const (
+	StatsIntervalDay   = "day"
+	StatsIntervalWeek  = "week"
+	StatsIntervalMonth = "month"
+	StatsIntervalYear  = "year"
+)
+
+// MaxStatsDays bounds the stats window; WeeklyStats does work per day in it.
+const MaxStatsDays = 5 * 365
+
+var defaultStatsDays = map[string]int{
+	StatsIntervalDay:   7,
+	StatsIntervalWeek:  12 * 7,
+	StatsIntervalMonth: 365,
+	StatsIntervalYear:  MaxStatsDays,
+}
+
+// dailyTotals holds the per-day aggregate stored in daily_rollups.
+type dailyTotals struct {
+	DailyKey          string
+	TotalVolumeMl     float64
+	TotalEffectiveMl  float64
+	TotalFoodMl       float64
+	TotalCaffeineMg   float64
+	TotalSugarG       float64
+	TotalAlcoholUnits float64
+	LogCount          int64
+}
+
+func (d dailyTotals) nutrients() dto.NutrientTotals {
+	return dto.NutrientTotals{
+		CaffeineMg:   d.TotalCaffeineMg,
+		SugarG:       d.TotalSugarG,
+		AlcoholUnits: d.TotalAlcoholUnits,
+	}
+}
+
+// WeeklyStats summarizes the trailing window of days ending today, grouped into buckets of
+// the requested interval. Totals come from the daily rollup table, so the cost grows with
+// the number of days rather than the number of logs. Streaks cover the user's full history
+// and are computed by StreakService, independent of the requested window.
+func (s *HydrationService) WeeklyStats(ctx context.Context, userID uuid.UUID, timezone string, days int, interval string) (*dto.HydrationStatsResponse, error) {
+	interval = strings.ToLower(strings.TrimSpace(interval))
+	if interval == "" {
+		interval = StatsIntervalDay
+	}
+	if _, ok := defaultStatsDays[interval]; !ok {
+		return nil, fmt.Errorf("unsupported interval: %s", interval)
+	}
+	if days <= 0 {
+		days = defaultStatsDays[interval]
+	}
+	if days > MaxStatsDays {
+		return nil, fmt.Errorf("days must be at most %d", MaxStatsDays)
+	}
+
+	user, err := s.fetchUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tz := user.Timezone
+	if strings.TrimSpace(timezone) != "" {
+		tz = timezone
+	}
+
+	loc, err := utils.LoadLocation(tz)
+	if err != nil {
+		return nil, err
+	}
+
+	endDateKey := utils.DailyKey(time.Now(), loc, user.DayStartOffsetMinutes)
+	today, err := time.Parse(time.DateOnly, endDateKey)
+	if err != nil {
+		return nil, fmt.Errorf("parse daily key: %w", err)
+	}
+	startRange := today.AddDate(0, 0, -days+1)
+	startDateKey := startRange.Format(time.DateOnly)
+
+	totals, err := s.aggregateDailyTotals(ctx, userID, startDateKey, endDateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	dailyGoals, err := s.dailyGoalSvc.GetDailyGoals(ctx, userID, startDateKey, endDateKey)
+	if err != nil {
+		return nil, fmt.Errorf("get daily goals: %w", err)
+	}
+
+	streaks, err := s.streakSvc.Compute(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("compute streaks: %w", err)
+	}
+
+	totalVolume := 0.0
+	totalEffective := 0.0
+	totalFromFood := 0.0
+
+	buckets := make([]dto.DailySummaryResponse, 0)
+	bucketIndex := make(map[string]int)
+
+	for i := 0; i < days; i++ {
+		key := startRange.AddDate(0, 0, i).Format(time.DateOnly)
+
+		goal := dailyGoals[key]
+		day := totals[key]
+		totalVolume += day.TotalVolumeMl
+		totalEffective += day.TotalEffectiveMl
+		totalFromFood += day.TotalFoodMl
+
+		bucketKey, err := statsBucketKey(key, interval)
+		if err != nil {
+			return nil, err
+		}
+
+		idx, exists := bucketIndex[bucketKey]
+		if !exists {
+			buckets = append(buckets, dto.DailySummaryResponse{
+				Date:             bucketKey,
+				Timezone:         loc.String(),
+				GoalBreakdown:    []dto.GoalBreakdownItem{},
+				Logs:             []dto.HydrationLogResponse{},
+				Exercise:         []dto.ExerciseSessionResponse{},
+				Containers:       []dto.ContainerResponse{},
+				NutrientWarnings: []dto.NutrientWarning{},
+			})
+			idx = len(buckets) - 1
+			bucketIndex[bucketKey] = idx
+		}
+
+		bucket := &buckets[idx]
+		bucket.TotalVolumeMl += day.TotalVolumeMl
+		bucket.TotalEffectiveMl += day.TotalEffectiveMl
+		bucket.FromFoodMl += day.TotalFoodMl
+		bucket.GoalVolumeMl += goal.GoalMl
+		bucket.Nutrients.CaffeineMg += day.TotalCaffeineMg
+		bucket.Nutrients.SugarG += day.TotalSugarG
+		bucket.Nutrients.AlcoholUnits += day.TotalAlcoholUnits
+		// Limits are daily, so longer buckets list each day that reached one.
+		bucket.NutrientWarnings = append(bucket.NutrientWarnings, nutrientWarnings(user, key, day.nutrients())...)
+		if interval == StatsIntervalDay {
+			bucket.GoalBreakdown = dto.NewGoalBreakdown(goal)
+		} else {
+			bucket.GoalBreakdown = mergeGoalBreakdown(bucket.GoalBreakdown, dto.NewGoalBreakdown(goal))
+		}
+	}
+
+	for i := range buckets {
+		buckets[i].ProgressPercentage = progressPercentage(buckets[i].TotalEffectiveMl, buckets[i].GoalVolumeMl)
+		buckets[i].Status = dayStatus(user, buckets[i].TotalEffectiveMl, buckets[i].GoalVolumeMl)
+	}
+
+	return &dto.HydrationStatsResponse{
+		UserID:           user.ID,
+		Timezone:         loc.String(),
+		Interval:         interval,
+		DailySummaries:   buckets,
+		StreakCount:      streaks.CurrentStreak,
+		BestStreak:       streaks.BestStreak,
+		TotalVolumeMl:    totalVolume,
+		TotalEffectiveMl: totalEffective,
+		TotalFromFoodMl:  totalFromFood,
+	}, nil
+}
+
+// aggregateDailyTotals reads per-day totals between two inclusive keys from daily_rollups.
+func (s *HydrationService) aggregateDailyTotals(ctx context.Context, userID uuid.UUID, startDateKey, endDateKey string) (map[string]dailyTotals, error) {
+	var rows []dailyTotals
+	if err := s.db.WithContext(ctx).
+		Model(&models.DailyRollup{}).
+		Select("daily_key, total_volume_ml, total_effective_ml, total_food_ml, total_caffeine_mg, total_sugar_g, total_alcohol_units, log_count").
+		Where("user_id = ? AND daily_key >= ? AND daily_key <= ?", userID, startDateKey, endDateKey).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("read daily rollups: %w", err)
+	}
+
+	totals := make(map[string]dailyTotals, len(rows))
+	for _, row := range rows {
+		totals[row.DailyKey] = row
+	}
+	return totals, nil
+}
+
+// mergeGoalBreakdown sums breakdown lines with the same component, reason and source so a
+// multi-day bucket explains its combined goal. Per-day inputs are dropped.
+func mergeGoalBreakdown(into, items []dto.GoalBreakdownItem) []dto.GoalBreakdownItem {
+	for _, item := range items {
+		merged := false
+		for i := range into {
+			if into[i].Component == item.Component && into[i].Reason == item.Reason && into[i].Source == item.Source {
+				into[i].AmountMl += item.AmountMl
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			item.Inputs = nil
+			into = append(into, item)
+		}
+	}
+	return into
+}
+
+// statsBucketKey maps a daily key to the first day of its bucket.
+// Weeks start on Monday.
+func statsBucketKey(dateKey, interval string) (string, error) {
+	date, err := time.Parse(time.DateOnly, dateKey)
+	if err != nil {
+		return "", fmt.Errorf("parse daily key: %w", err)
+	}
+
+	switch interval {
+	case StatsIntervalWeek:
+		offset := (int(date.Weekday()) + 6) % 7
+		return date.AddDate(0, 0, -offset).Format(time.DateOnly), nil
+	case StatsIntervalMonth:
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC).Format(time.DateOnly), nil
+	case StatsIntervalYear:
+		return time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, time.UTC).Format(time.DateOnly), nil
+	default:
+		return dateKey, nil
+	}
+}
+
+func defaultGoalMl(user *models.User) float64 {
+	goalMl := user.DailyGoalLiters * 1000
+	if goalMl == 0 {
+		goalMl = 2000
+	}
+	return goalMl
+}
+
+func progressPercentage(totalEffectiveMl, goalMl float64) float64 {
+	if goalMl <= 0 {
+		return 0
+	}
+	return (totalEffectiveMl / goalMl) * 100
+}
+
+// Day statuses in fluid restriction mode, where the goal is an upper limit.
+const (
+	StatusUnderLimit       = "under_limit"
+	StatusApproachingLimit = "approaching_limit"
+	StatusOverLimit        = "over_limit"
+)
+
+// approachingLimitShare is the share of the limit from which a restricted day is flagged.
+const approachingLimitShare = 0.9
+
+// dayStatus reports progress against the goal, or against the limit in restriction mode.
+func dayStatus(user *models.User, totalEffectiveMl, goalMl float64) string {
+	if user.FluidRestrictionEnabled {
+		return limitStatus(totalEffectiveMl, goalMl)
+	}
+	return summaryStatus(totalEffectiveMl, goalMl)
+}
+
+func limitStatus(totalEffectiveMl, limitMl float64) string {
+	if totalEffectiveMl > limitMl {
+		return StatusOverLimit
+	}
+	if totalEffectiveMl >= limitMl*approachingLimitShare {
+		return StatusApproachingLimit
+	}
+	return StatusUnderLimit
+}
+
+func summaryStatus(totalEffectiveMl, goalMl float64) string {
+	if totalEffectiveMl >= goalMl {
+		return "completed"
+	}
+	if totalEffectiveMl > 0 {
+		return "in_progress"
+	}
+	return "not_started"
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+)
+
+// Nutrients with a user-configurable daily limit, as reported by NutrientWarning.
+const (
+	NutrientCaffeine = "caffeine"
+	NutrientAlcohol  = "alcohol"
+)
+
+func validateDrinkNutrients(drink *models.Drink) error {
+	if drink.CaffeineMgPer100Ml < 0 || drink.SugarGPer100Ml < 0 || drink.AlcoholUnitsPer100Ml < 0 {
+		return fmt.Errorf("nutrient values cannot be negative")
+	}
+	if drink.WaterContentRatio < 0 || drink.WaterContentRatio > 1 {
+		return fmt.Errorf("waterContentRatio must be between 0 and 1")
+	}
+	return nil
+}
+
+// foodWaterMl is the water in a food portion, counting one gram of water as one milliliter.
+func foodWaterMl(massG float64, ratio *float64) (float64, error) {
+	if massG <= 0 {
+		return 0, fmt.Errorf("mass must be positive")
+	}
+	if ratio == nil || *ratio <= 0 || *ratio > 1 {
+		return 0, fmt.Errorf("food logged by mass needs a waterContentRatio between 0 and 1")
+	}
+	return massG * *ratio, nil
+}
+
+// nutrientBasis is the amount a log's nutrients scale with: the mass in grams for food logged by
+// mass, otherwise the volume in milliliters. Food nutrients are per 100 g of the whole portion,
+// not of the water it contains.
+func nutrientBasis(logEntry *models.HydrationLog) float64 {
+	if logEntry.MassG != nil {
+		return *logEntry.MassG
+	}
+	return logEntry.VolumeMl
+}
+
+// applyDrinkNutrients copies the drink's nutrients onto the log for the log's volume, or its
+// mass for food. A nil drink clears them.
+func applyDrinkNutrients(logEntry *models.HydrationLog, drink *models.Drink) {
+	if drink == nil {
+		logEntry.CaffeineMg, logEntry.SugarG, logEntry.AlcoholUnits = 0, 0, 0
+		return
+	}
+	share := nutrientBasis(logEntry) / 100
+	logEntry.CaffeineMg = drink.CaffeineMgPer100Ml * share
+	logEntry.SugarG = drink.SugarGPer100Ml * share
+	logEntry.AlcoholUnits = drink.AlcoholUnitsPer100Ml * share
+}
+
+// scaleNutrients keeps a log's copied nutrients proportional when only its volume or mass
+// changes. previousBasis is the log's nutrientBasis before the change.
+func scaleNutrients(logEntry *models.HydrationLog, previousBasis float64) {
+	basis := nutrientBasis(logEntry)
+	if previousBasis <= 0 || previousBasis == basis {
+		return
+	}
+	ratio := basis / previousBasis
+	logEntry.CaffeineMg *= ratio
+	logEntry.SugarG *= ratio
+	logEntry.AlcoholUnits *= ratio
+}
+
+// nutrientWarnings compares a day's totals against the user's caffeine and alcohol limits,
+// flagging days from approachingLimitShare of a limit onwards.
+func nutrientWarnings(user *models.User, date string, totals dto.NutrientTotals) []dto.NutrientWarning {
+	warnings := make([]dto.NutrientWarning, 0)
+	limits := []struct {
+		nutrient string
+		total    float64
+		limit    *float64
+	}{
+		{NutrientCaffeine, totals.CaffeineMg, user.CaffeineLimitMg},
+		{NutrientAlcohol, totals.AlcoholUnits, user.AlcoholLimitUnits},
+	}
+	for _, limit := range limits {
+		if limit.limit == nil || *limit.limit <= 0 {
+			continue
+		}
+		status := limitStatus(limit.total, *limit.limit)
+		if status == StatusUnderLimit {
+			continue
+		}
+		warnings = append(warnings, dto.NutrientWarning{
+			Date:     date,
+			Nutrient: limit.nutrient,
+			Total:    limit.total,
+			Limit:    *limit.limit,
+			Status:   status,
+		})
+	}
+	return warnings
+}
@@ -0,0 +1,356 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RollupService rebuilds day-keyed data derived from raw hydration logs: the daily_rollups
+// table and the DailyKey of each log when a user's day boundary changes.
+type RollupService struct {
+	db *gorm.DB
+}
+
+func NewRollupService(db *gorm.DB) *RollupService {
+	return &RollupService{db: db}
+}
+
+// RebuildUser discards and recomputes every rollup for one user.
+func (s *RollupService) RebuildUser(ctx context.Context, userID uuid.UUID) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return rebuildUserRollups(tx, userID)
+	})
+}
+
+// RebuildAll recomputes rollups for every user, one transaction per user.
+func (s *RollupService) RebuildAll(ctx context.Context) (int, error) {
+	var userIDs []uuid.UUID
+	if err := s.db.WithContext(ctx).Model(&models.User{}).Pluck("id", &userIDs).Error; err != nil {
+		return 0, fmt.Errorf("list users: %w", err)
+	}
+
+	for _, userID := range userIDs {
+		if err := s.RebuildUser(ctx, userID); err != nil {
+			return 0, fmt.Errorf("rebuild rollups for %s: %w", userID, err)
+		}
+	}
+
+	return len(userIDs), nil
+}
+
+// RekeyUserLogs recomputes DailyKey for all of a user's logs, including trashed ones, using
+// each log's recorded timezone and the user's current day start, then rebuilds rollups. The
+// other day-keyed entries move with them in the same transaction (see rekeyUserDays).
+func (s *RollupService) RekeyUserLogs(ctx context.Context, userID uuid.UUID) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.First(&user, "id = ?", userID).Error; err != nil {
+			return fmt.Errorf("fetch user: %w", err)
+		}
+
+		if _, err := rekeyUserDays(tx, &user, nil); err != nil {
+			return err
+		}
+
+		return tx.Model(&models.User{}).Where("id = ?", userID).Update("day_start_rekey_pending", false).Error
+	})
+}
+
+// RekeyPending re-keys logs for every user whose day start changed since their last rekey.
+func (s *RollupService) RekeyPending(ctx context.Context) (int, error) {
+	var userIDs []uuid.UUID
+	if err := s.db.WithContext(ctx).Model(&models.User{}).
+		Where("day_start_rekey_pending = ?", true).
+		Pluck("id", &userIDs).Error; err != nil {
+		return 0, fmt.Errorf("list users pending rekey: %w", err)
+	}
+
+	for _, userID := range userIDs {
+		if err := s.RekeyUserLogs(ctx, userID); err != nil {
+			return 0, fmt.Errorf("rekey logs for %s: %w", userID, err)
+		}
+	}
+
+	return len(userIDs), nil
+}
+
+// rekeyWindow limits a rekey to entries recorded between From and To and moves them onto
+// Timezone. When FromTimezone is set only entries recorded in that zone are moved.
+type rekeyWindow struct {
+	From         time.Time
+	To           time.Time
+	Timezone     string
+	FromTimezone string
+}
+
+// rekeyedEntry is a row whose timezone or daily key was rewritten.
+type rekeyedEntry struct {
+	ID          uuid.UUID
+	RecordedAt  time.Time
+	OldTimezone string
+	NewTimezone string
+	OldKey      string
+	NewKey      string
+}
+
+// rekeyResult lists what rekeyUserDays moved. ChangedDays holds every old and new key of the
+// moved entries, sorted.
+type rekeyResult struct {
+	Logs        []rekeyedEntry
+	Sessions    []rekeyedEntry
+	ChangedDays []string
+}
+
+// rekeyUserDays recomputes the daily key of every day-keyed entry of the user: hydration logs
+// (trashed ones included), safety warnings, exercise sessions, sweat tests and weight
+// measurements. Without a window each entry keeps its recorded timezone and only the current day
+// start is reapplied; with one, the entries inside it move onto window.Timezone. Goals fed by
+// the moved entries and the affected rollups are refreshed in the same transaction.
+func rekeyUserDays(tx *gorm.DB, user *models.User, window *rekeyWindow) (*rekeyResult, error) {
+	logs, err := rekeyTable(tx, user, "hydration_logs", "consumed_at", true, window)
+	if err != nil {
+		return nil, fmt.Errorf("rekey hydration logs: %w", err)
+	}
+	for _, entry := range logs {
+		if err := recordSyncChange(tx, user.ID, models.SyncEntityHydrationLog, entry.ID, models.SyncOperationUpsert); err != nil {
+			return nil, err
+		}
+	}
+
+	// Safety warnings take the key of the log that triggered them; warnings whose log was
+	// purged fall back to the trigger time.
+	zone := defaultString(user.Timezone, "UTC")
+	warningFilter := ""
+	args := []interface{}{}
+	if window != nil {
+		zone = window.Timezone
+		warningFilter = " AND w.triggered_at >= ? AND w.triggered_at <= ?"
+		args = append(args, window.From, window.To)
+	}
+	if err := tx.Exec(`
+		UPDATE safety_warnings w
+		SET daily_key = COALESCE(
+			(SELECT l.daily_key FROM hydration_logs l WHERE l.id = w.hydration_log_id),
+			to_char((w.triggered_at AT TIME ZONE ?) - make_interval(mins => ?), 'YYYY-MM-DD')
+		)
+		WHERE w.user_id = ?`+warningFilter,
+		append([]interface{}{zone, user.DayStartOffsetMinutes, user.ID}, args...)...).Error; err != nil {
+		return nil, fmt.Errorf("rekey safety warnings: %w", err)
+	}
+
+	// Exercise sessions take their goal bonus with them.
+	sessions, err := rekeyTable(tx, user, "exercise_sessions", "started_at", true, window)
+	if err != nil {
+		return nil, fmt.Errorf("rekey exercise sessions: %w", err)
+	}
+	exerciseDays := make([]string, 0, len(sessions)*2)
+	for _, entry := range sessions {
+		if entry.OldKey != entry.NewKey {
+			exerciseDays = append(exerciseDays, entry.OldKey, entry.NewKey)
+		}
+	}
+	if err := refreshGoalAdjustments(tx, user, exerciseDays...); err != nil {
+		return nil, err
+	}
+
+	// A sweat test counts towards the personal sweat rate from its day onwards.
+	sweatTests, err := rekeyTable(tx, user, "sweat_tests", "started_at", true, window)
+	if err != nil {
+		return nil, fmt.Errorf("rekey sweat tests: %w", err)
+	}
+	firstSweatDay := ""
+	for _, entry := range sweatTests {
+		for _, key := range []string{entry.OldKey, entry.NewKey} {
+			if firstSweatDay == "" || key < firstSweatDay {
+				firstSweatDay = key
+			}
+		}
+	}
+	if firstSweatDay != "" {
+		if err := refreshSweatRateGoals(tx, user, firstSweatDay); err != nil {
+			return nil, err
+		}
+	}
+
+	// Weight measurements decide which weight each day's goal uses.
+	weights, err := rekeyTable(tx, user, "weight_measurements", "measured_at", false, window)
+	if err != nil {
+		return nil, fmt.Errorf("rekey weight measurements: %w", err)
+	}
+	if len(weights) > 0 {
+		if err := resnapshotUpcomingGoals(tx, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	touched := make(map[string]bool)
+	for _, entry := range append(append([]rekeyedEntry{}, logs...), sessions...) {
+		if entry.OldKey != entry.NewKey {
+			touched[entry.OldKey] = true
+			touched[entry.NewKey] = true
+		}
+	}
+	result := &rekeyResult{Logs: logs, Sessions: sessions, ChangedDays: make([]string, 0, len(touched))}
+	for key := range touched {
+		result.ChangedDays = append(result.ChangedDays, key)
+	}
+	sort.Strings(result.ChangedDays)
+
+	if window == nil {
+		err = rebuildUserRollups(tx, user.ID)
+	} else {
+		err = refreshDailyRollups(tx, user.ID, result.ChangedDays...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// rekeyTable rewrites the daily key of the user's rows in table from their instant column, as
+// described by rekeyUserDays, and returns the rows it changed ordered by that instant.
+func rekeyTable(tx *gorm.DB, user *models.User, table, instant string, hasUpdatedAt bool, window *rekeyWindow) ([]rekeyedEntry, error) {
+	// Wall-clock subtraction mirrors utils.DailyKey.
+	zone := "COALESCE(NULLIF(timezone, ''), 'UTC')"
+	filter := "user_id = ?"
+	set := "daily_key = moved.new_key"
+	changed := "t.daily_key IS DISTINCT FROM moved.new_key"
+	args := []interface{}{}
+	if window != nil {
+		zone = "?::text"
+		args = append(args, window.Timezone)
+	}
+	args = append(args, user.ID)
+	if window != nil {
+		filter += fmt.Sprintf(" AND %[1]s >= ? AND %[1]s <= ? AND timezone <> ?", instant)
+		args = append(args, window.From, window.To, window.Timezone)
+		if window.FromTimezone != "" {
+			filter += " AND timezone = ?"
+			args = append(args, window.FromTimezone)
+		}
+		set += ", timezone = moved.new_timezone"
+		changed += " OR t.timezone IS DISTINCT FROM moved.new_timezone"
+	}
+	if hasUpdatedAt {
+		set += ", updated_at = NOW()"
+	}
+	args = append(args, user.DayStartOffsetMinutes)
+
+	var entries []rekeyedEntry
+	if err := tx.Raw(fmt.Sprintf(`
+		WITH zoned AS (
+			SELECT id, %[2]s AS recorded_at, timezone AS old_timezone, daily_key AS old_key, %[3]s AS new_timezone
+			FROM %[1]s
+			WHERE %[4]s
+		), moved AS (
+			SELECT id, recorded_at, old_timezone, old_key, new_timezone,
+				to_char((recorded_at AT TIME ZONE new_timezone) - make_interval(mins => ?), 'YYYY-MM-DD') AS new_key
+			FROM zoned
+		)
+		UPDATE %[1]s t
+		SET %[5]s
+		FROM moved
+		WHERE t.id = moved.id AND (%[6]s)
+		RETURNING t.id, moved.recorded_at, moved.old_timezone, moved.new_timezone, moved.old_key, moved.new_key
+	`, table, instant, zone, filter, set, changed), args...).Scan(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].RecordedAt.Before(entries[j].RecordedAt) })
+	return entries, nil
+}
+
+func rebuildUserRollups(tx *gorm.DB, userID uuid.UUID) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&models.DailyRollup{}).Error; err != nil {
+		return fmt.Errorf("clear daily rollups: %w", err)
+	}
+
+	var dailyKeys []string
+	if err := tx.Model(&models.HydrationLog{}).
+		Where("user_id = ?", userID).
+		Distinct().
+		Pluck("daily_key", &dailyKeys).Error; err != nil {
+		return fmt.Errorf("collect daily keys: %w", err)
+	}
+
+	return refreshDailyRollups(tx, userID, dailyKeys...)
+}
+
+// refreshDailyRollups recomputes the rollup rows for the given days from raw logs.
+// Days with activity get their goal snapshotted first, so the stored goal is the one in effect.
+// Callers pass their transaction so the rollup commits together with the log write.
+func refreshDailyRollups(tx *gorm.DB, userID uuid.UUID, dailyKeys ...string) error {
+	var user *models.User
+	seen := make(map[string]bool, len(dailyKeys))
+	for _, key := range dailyKeys {
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		var totals dailyTotals
+		if err := tx.Model(&models.HydrationLog{}).
+			Select("COALESCE(SUM(volume_ml), 0) AS total_volume_ml, COALESCE(SUM(effective_ml), 0) AS total_effective_ml, "+
+				"COALESCE(SUM(CASE WHEN mass_g IS NOT NULL THEN effective_ml END), 0) AS total_food_ml, "+
+				"COALESCE(SUM(caffeine_mg), 0) AS total_caffeine_mg, COALESCE(SUM(sugar_g), 0) AS total_sugar_g, "+
+				"COALESCE(SUM(alcohol_units), 0) AS total_alcohol_units, COUNT(*) AS log_count").
+			Where("user_id = ? AND daily_key = ?", userID, key).
+			Scan(&totals).Error; err != nil {
+			return fmt.Errorf("aggregate day %s: %w", key, err)
+		}
+
+		if totals.LogCount == 0 {
+			if err := tx.Where("user_id = ? AND daily_key = ?", userID, key).Delete(&models.DailyRollup{}).Error; err != nil {
+				return fmt.Errorf("clear daily rollup %s: %w", key, err)
+			}
+			continue
+		}
+
+		if err := snapshotDailyGoals(tx, userID, key); err != nil {
+			return err
+		}
+
+		goalMl, err := resolveDailyGoalMl(tx, userID, key)
+		if err != nil {
+			return fmt.Errorf("resolve goal for %s: %w", key, err)
+		}
+
+		if user == nil {
+			user = &models.User{}
+			if err := tx.First(user, "id = ?", userID).Error; err != nil {
+				return fmt.Errorf("fetch user: %w", err)
+			}
+		}
+
+		rollup := models.DailyRollup{
+			UserID:            userID,
+			DailyKey:          key,
+			TotalVolumeMl:     totals.TotalVolumeMl,
+			TotalEffectiveMl:  totals.TotalEffectiveMl,
+			TotalFoodMl:       totals.TotalFoodMl,
+			TotalCaffeineMg:   totals.TotalCaffeineMg,
+			TotalSugarG:       totals.TotalSugarG,
+			TotalAlcoholUnits: totals.TotalAlcoholUnits,
+			LogCount:          totals.LogCount,
+			GoalMl:            goalMl,
+			Status:            dayStatus(user, totals.TotalEffectiveMl, goalMl),
+			UpdatedAt:         time.Now().UTC(),
+		}
+
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "daily_key"}},
+			DoUpdates: clause.AssignmentColumns([]string{"total_volume_ml", "total_effective_ml", "total_food_ml", "total_caffeine_mg", "total_sugar_g", "total_alcohol_units", "log_count", "goal_ml", "status", "updated_at"}),
+		}).Create(&rollup).Error; err != nil {
+			return fmt.Errorf("upsert daily rollup %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
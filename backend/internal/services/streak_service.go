@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/AD-Archer/archer-aqua/backend/internal/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// streakFreezeEarnDays is how many consecutive completed days earn one streak freeze.
+	streakFreezeEarnDays = 7
+	// maxStreakFreezes caps how many freezes can be banked at once.
+	maxStreakFreezes = 2
+)
+
+var (
+	ErrExcusedDayNotFound = errors.New("excused day not found")
+	ErrInvalidExcuse      = errors.New("reason must be one of sick, travel, other")
+)
+
+var excuseReasons = map[string]bool{"sick": true, "travel": true, "other": true}
+
+// StreakService computes hydration streaks across a user's full history.
+//
+// Rules, applied day by day from the first tracked day up to yesterday:
+//   - a completed day extends the streak;
+//   - an excused day neither extends nor breaks it;
+//   - a missed day consumes a banked freeze if one is available, otherwise resets the streak.
+//
+// Every streakFreezeEarnDays consecutive completed days earn one freeze, up to maxStreakFreezes.
+// Today is pending: it extends the streak once completed but never breaks it.
+// In fluid restriction mode a day completes by ending at or under its limit, so today is
+// never counted until it is over.
+type StreakService struct {
+	db *gorm.DB
+}
+
+func NewStreakService(db *gorm.DB) *StreakService {
+	return &StreakService{db: db}
+}
+
+// Compute returns the current and all-time streaks for a user as of now in their timezone.
+func (s *StreakService) Compute(ctx context.Context, userID uuid.UUID) (*dto.StreakResponse, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).First(&user, "id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, fmt.Errorf("fetch user: %w", err)
+	}
+
+	loc, err := utils.LoadLocation(user.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	today := utils.DailyKey(time.Now(), loc, user.DayStartOffsetMinutes)
+
+	var rollups []models.DailyRollup
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ? AND daily_key <= ?", userID, today).
+		Order("daily_key ASC").
+		Find(&rollups).Error; err != nil {
+		return nil, fmt.Errorf("fetch daily rollups: %w", err)
+	}
+
+	var excused []models.ExcusedDay
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ? AND date <= ?", userID, today).
+		Find(&excused).Error; err != nil {
+		return nil, fmt.Errorf("fetch excused days: %w", err)
+	}
+
+	// In fluid restriction mode a tracked day succeeds by staying within the limit.
+	completed := make(map[string]bool, len(rollups))
+	tracked := make(map[string]bool, len(rollups))
+	overLimitToday := false
+	for _, rollup := range rollups {
+		tracked[rollup.DailyKey] = true
+		if user.FluidRestrictionEnabled {
+			if rollup.TotalEffectiveMl <= rollup.GoalMl {
+				completed[rollup.DailyKey] = true
+			} else if rollup.DailyKey == today {
+				overLimitToday = true
+			}
+			continue
+		}
+		if rollup.TotalEffectiveMl >= rollup.GoalMl {
+			completed[rollup.DailyKey] = true
+		}
+	}
+	excusedSet := make(map[string]bool, len(excused))
+	for _, day := range excused {
+		excusedSet[day.Date] = true
+	}
+
+	response := &dto.StreakResponse{
+		TodayStatus: "pending",
+		FrozenDays:  []string{},
+		ExcusedDays: []string{},
+	}
+
+	if len(rollups) == 0 {
+		return response, nil
+	}
+
+	start, err := time.Parse(time.DateOnly, rollups[0].DailyKey)
+	if err != nil {
+		return nil, fmt.Errorf("parse daily key: %w", err)
+	}
+
+	streak, best, run, freezes := 0, 0, 0, 0
+	for day := start; ; day = day.AddDate(0, 0, 1) {
+		key := day.Format(time.DateOnly)
+		if key >= today {
+			break
+		}
+
+		// A restricted day without logs drank nothing, which is under any limit.
+		untrackedUnderLimit := user.FluidRestrictionEnabled && !tracked[key]
+
+		switch {
+		case completed[key] || untrackedUnderLimit:
+			streak++
+			run++
+			if run%streakFreezeEarnDays == 0 && freezes < maxStreakFreezes {
+				freezes++
+			}
+		case excusedSet[key]:
+			if streak > 0 {
+				response.ExcusedDays = append(response.ExcusedDays, key)
+			}
+		case freezes > 0 && streak > 0:
+			freezes--
+			run = 0
+			response.FrozenDays = append(response.FrozenDays, key)
+		default:
+			streak, run = 0, 0
+			response.FrozenDays = response.FrozenDays[:0]
+			response.ExcusedDays = response.ExcusedDays[:0]
+		}
+
+		if streak > best {
+			best = streak
+		}
+	}
+
+	if user.FluidRestrictionEnabled {
+		// Staying under a limit is only settled once the day is over.
+		switch {
+		case excusedSet[today]:
+			response.TodayStatus = "excused"
+		case overLimitToday:
+			response.TodayStatus = StatusOverLimit
+		}
+	} else if completed[today] {
+		streak++
+		response.TodayStatus = "completed"
+		if streak > best {
+			best = streak
+		}
+	} else if excusedSet[today] {
+		response.TodayStatus = "excused"
+	}
+
+	response.CurrentStreak = streak
+	response.BestStreak = best
+	response.FreezesAvailable = freezes
+	return response, nil
+}
+
+// ListExcusedDays returns the user's excused days, newest first.
+func (s *StreakService) ListExcusedDays(ctx context.Context, userID uuid.UUID) ([]models.ExcusedDay, error) {
+	var days []models.ExcusedDay
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("date DESC").
+		Find(&days).Error; err != nil {
+		return nil, fmt.Errorf("list excused days: %w", err)
+	}
+	return days, nil
+}
+
+// SetExcusedDay flags a date as excused, replacing any existing reason for that date.
+func (s *StreakService) SetExcusedDay(ctx context.Context, userID uuid.UUID, input dto.ExcusedDayRequest) (*models.ExcusedDay, error) {
+	reason := strings.ToLower(defaultString(strings.TrimSpace(input.Reason), "other"))
+	if !excuseReasons[reason] {
+		return nil, ErrInvalidExcuse
+	}
+
+	day := models.ExcusedDay{
+		UserID: userID,
+		Date:   input.Date,
+		Reason: reason,
+		Note:   input.Note,
+	}
+
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"reason", "note", "updated_at"}),
+	}).Create(&day).Error; err != nil {
+		return nil, fmt.Errorf("save excused day: %w", err)
+	}
+
+	if err := s.db.WithContext(ctx).First(&day, "user_id = ? AND date = ?", userID, input.Date).Error; err != nil {
+		return nil, fmt.Errorf("fetch excused day: %w", err)
+	}
+
+	return &day, nil
+}
+
+// DeleteExcusedDay removes the excuse for a date.
+func (s *StreakService) DeleteExcusedDay(ctx context.Context, userID uuid.UUID, date string) error {
+	result := s.db.WithContext(ctx).Where("user_id = ? AND date = ?", userID, date).Delete(&models.ExcusedDay{})
+	if result.Error != nil {
+		return fmt.Errorf("delete excused day: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrExcusedDayNotFound
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/AD-Archer/archer-aqua/backend/internal/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// sweatRateSampleSize is how many recent tests are averaged into the personal sweat rate.
+const sweatRateSampleSize = 3
+
+// maxSweatReplacementMlPerHour caps the recommended intake during exercise at roughly what the
+// gut can absorb; heavy sweaters make up the rest after the session.
+const maxSweatReplacementMlPerHour = 1000
+
+var ErrSweatTestNotFound = errors.New("sweat test not found")
+
+// SweatTestService records sweat tests and keeps their history. When the user enables sweat rate
+// goals, the exercise goal adjuster uses the personal rate instead of the intensity estimates,
+// so every write re-runs the adjusters for the exercise days the test can affect.
+type SweatTestService struct {
+	db *gorm.DB
+}
+
+func NewSweatTestService(db *gorm.DB) *SweatTestService {
+	return &SweatTestService{db: db}
+}
+
+// ListSweatTests returns tests between two inclusive daily keys, newest first; empty bounds are open.
+func (s *SweatTestService) ListSweatTests(ctx context.Context, userID uuid.UUID, from, to string) ([]models.SweatTest, error) {
+	query := s.db.WithContext(ctx).Where("user_id = ?", userID)
+	if from != "" {
+		query = query.Where("daily_key >= ?", from)
+	}
+	if to != "" {
+		query = query.Where("daily_key <= ?", to)
+	}
+
+	var tests []models.SweatTest
+	if err := query.Preload("HydrationLogs").Order("started_at DESC").Find(&tests).Error; err != nil {
+		return nil, fmt.Errorf("list sweat tests: %w", err)
+	}
+	return tests, nil
+}
+
+func (s *SweatTestService) CreateSweatTest(ctx context.Context, userID uuid.UUID, input dto.CreateSweatTestRequest) (*models.SweatTest, error) {
+	user, err := s.fetchUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	unit := defaultString(input.PreWeight.Unit, "kg")
+	preWeightKg, err := utils.ConvertWeightToKg(input.PreWeight.Value, unit)
+	if err != nil {
+		return nil, err
+	}
+	postWeightKg, err := utils.ConvertWeightToKg(input.PostWeight.Value, defaultString(input.PostWeight.Unit, unit))
+	if err != nil {
+		return nil, err
+	}
+	if preWeightKg <= 0 || postWeightKg <= 0 {
+		return nil, fmt.Errorf("preWeight and postWeight must be positive")
+	}
+
+	urineMl := 0.0
+	if input.Urine != nil {
+		if urineMl, err = utils.ConvertVolumeToMl(input.Urine.Value, input.Urine.Unit); err != nil {
+			return nil, err
+		}
+		if urineMl < 0 {
+			return nil, fmt.Errorf("urine cannot be negative")
+		}
+	}
+
+	if input.DurationMinutes <= 0 || input.DurationMinutes > maxExerciseMinutes {
+		return nil, fmt.Errorf("durationMinutes must be between 1 and %d", maxExerciseMinutes)
+	}
+	if input.HumidityPercent != nil && (*input.HumidityPercent < 0 || *input.HumidityPercent > 100) {
+		return nil, fmt.Errorf("humidityPercent must be between 0 and 100")
+	}
+
+	startedAt := time.Now().UTC().Add(-time.Duration(input.DurationMinutes) * time.Minute)
+	if input.StartedAt != nil {
+		startedAt = input.StartedAt.UTC()
+	}
+	loc, err := utils.LoadLocation(defaultString(input.Timezone, user.Timezone))
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := s.fetchIntakeLogs(ctx, userID, input.HydrationLogIDs)
+	if err != nil {
+		return nil, err
+	}
+	intakeMl := 0.0
+	for _, log := range logs {
+		intakeMl += log.VolumeMl
+	}
+
+	test := models.SweatTest{
+		UserID:          userID,
+		Activity:        strings.TrimSpace(input.Activity),
+		StartedAt:       startedAt,
+		DurationMinutes: input.DurationMinutes,
+		Timezone:        loc.String(),
+		DailyKey:        utils.DailyKey(startedAt, loc, user.DayStartOffsetMinutes),
+		PreWeightKg:     preWeightKg,
+		PostWeightKg:    postWeightKg,
+		WeightUnit:      unit,
+		FluidIntakeMl:   intakeMl,
+		UrineMl:         urineMl,
+		TemperatureC:    input.TemperatureC,
+		HumidityPercent: input.HumidityPercent,
+		Notes:           input.Notes,
+	}
+	test.SweatLossMl = (preWeightKg-postWeightKg)*1000 + intakeMl - urineMl
+	if test.SweatLossMl <= 0 {
+		return nil, fmt.Errorf("weights and intake give no sweat loss; check the pre- and post-session weights")
+	}
+	test.SweatRateLPerHour = test.SweatLossMl / 1000 / (float64(test.DurationMinutes) / 60)
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&test).Error; err != nil {
+			return fmt.Errorf("create sweat test: %w", err)
+		}
+		for i := range logs {
+			logs[i].SweatTestID = &test.ID
+			if err := tx.Model(&models.HydrationLog{}).Where("id = ?", logs[i].ID).Update("sweat_test_id", test.ID).Error; err != nil {
+				return fmt.Errorf("link hydration log: %w", err)
+			}
+			if err := recordSyncChange(tx, userID, models.SyncEntityHydrationLog, logs[i].ID, models.SyncOperationUpsert); err != nil {
+				return err
+			}
+		}
+		return refreshSweatRateGoals(tx, user, test.DailyKey)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	test.HydrationLogs = logs
+	return &test, nil
+}
+
+// DeleteSweatTest removes a test; its logs stay but are unlinked.
+func (s *SweatTestService) DeleteSweatTest(ctx context.Context, userID, testID uuid.UUID) error {
+	user, err := s.fetchUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var test models.SweatTest
+	if err := s.db.WithContext(ctx).First(&test, "id = ? AND user_id = ?", testID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSweatTestNotFound
+		}
+		return fmt.Errorf("fetch sweat test: %w", err)
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var logIDs []uuid.UUID
+		if err := tx.Unscoped().Model(&models.HydrationLog{}).
+			Where("sweat_test_id = ?", test.ID).
+			Pluck("id", &logIDs).Error; err != nil {
+			return fmt.Errorf("fetch linked hydration logs: %w", err)
+		}
+		if len(logIDs) > 0 {
+			if err := tx.Unscoped().Model(&models.HydrationLog{}).
+				Where("id IN ?", logIDs).
+				Update("sweat_test_id", nil).Error; err != nil {
+				return fmt.Errorf("unlink hydration logs: %w", err)
+			}
+			for _, logID := range logIDs {
+				if err := recordSyncChange(tx, userID, models.SyncEntityHydrationLog, logID, models.SyncOperationUpsert); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := tx.Delete(&models.SweatTest{}, "id = ?", test.ID).Error; err != nil {
+			return fmt.Errorf("delete sweat test: %w", err)
+		}
+		return refreshSweatRateGoals(tx, user, test.DailyKey)
+	})
+}
+
+// SweatReplacementMlPerHour is the recommended intake per hour of a session like the test.
+func SweatReplacementMlPerHour(test models.SweatTest) float64 {
+	return sweatReplacementMlPerHour(test.SweatRateLPerHour)
+}
+
+// sweatReplacementMlPerHour turns a sweat rate in L/h into the recommended intake per hour: the
+// rate, capped at what can be absorbed during exercise. The exercise goal adjustment uses it too,
+// so the goal bonus matches the recommendation shown with each test.
+func sweatReplacementMlPerHour(rateLPerHour float64) float64 {
+	return roundToNearest(math.Min(rateLPerHour*1000, maxSweatReplacementMlPerHour), 10)
+}
+
+// fetchIntakeLogs loads the logs to link to a new test, rejecting unknown logs and logs that
+// already belong to another test.
+func (s *SweatTestService) fetchIntakeLogs(ctx context.Context, userID uuid.UUID, logIDs []uuid.UUID) ([]models.HydrationLog, error) {
+	if len(logIDs) == 0 {
+		return []models.HydrationLog{}, nil
+	}
+
+	unique := make(map[uuid.UUID]bool, len(logIDs))
+	for _, id := range logIDs {
+		unique[id] = true
+	}
+
+	var logs []models.HydrationLog
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ? AND id IN ?", userID, logIDs).
+		Find(&logs).Error; err != nil {
+		return nil, fmt.Errorf("fetch hydration logs: %w", err)
+	}
+	if len(logs) != len(unique) {
+		return nil, ErrHydrationLogNotFound
+	}
+	for _, log := range logs {
+		if log.SweatTestID != nil {
+			return nil, fmt.Errorf("hydration log %s already belongs to a sweat test", log.ID)
+		}
+	}
+	return logs, nil
+}
+
+// refreshSweatRateGoals re-runs the goal adjusters on exercise days from date onwards, since the
+// personal sweat rate for those days includes tests up to and including them.
+func refreshSweatRateGoals(tx *gorm.DB, user *models.User, date string) error {
+	if !user.SweatRateGoalsEnabled || user.FluidRestrictionEnabled {
+		return nil
+	}
+	return refreshExerciseGoals(tx, user, date)
+}
+
+// refreshExerciseGoals re-runs the goal adjusters on every exercise day from date onwards,
+// planned sessions included.
+func refreshExerciseGoals(tx *gorm.DB, user *models.User, date string) error {
+	var dates []string
+	if err := tx.Model(&models.ExerciseSession{}).
+		Where("user_id = ? AND daily_key >= ?", user.ID, date).
+		Distinct().
+		Pluck("daily_key", &dates).Error; err != nil {
+		return fmt.Errorf("fetch exercise days: %w", err)
+	}
+	return reapplyGoalAdjustments(tx, user, dates...)
+}
+
+// loadSweatTests returns the user's most recent tests up to date, newest first, for personalSweatRate.
+func loadSweatTests(tx *gorm.DB, userID uuid.UUID, date string) ([]models.SweatTest, error) {
+	var tests []models.SweatTest
+	if err := tx.Where("user_id = ? AND daily_key <= ?", userID, date).
+		Order("started_at DESC").
+		Limit(50).
+		Find(&tests).Error; err != nil {
+		return nil, fmt.Errorf("load sweat tests: %w", err)
+	}
+	return tests, nil
+}
+
+// personalSweatRate averages the sweat rate of the latest tests for the activity, or of the
+// latest tests overall when none match. It returns the rate in L/h and the number of tests used.
+func personalSweatRate(tests []models.SweatTest, activity string) (float64, int) {
+	matching := make([]models.SweatTest, 0, sweatRateSampleSize)
+	for _, test := range tests {
+		if activity != "" && strings.EqualFold(test.Activity, activity) {
+			matching = append(matching, test)
+		}
+	}
+	if len(matching) == 0 {
+		matching = tests
+	}
+	if len(matching) > sweatRateSampleSize {
+		matching = matching[:sweatRateSampleSize]
+	}
+	if len(matching) == 0 {
+		return 0, 0
+	}
+
+	total := 0.0
+	for _, test := range matching {
+		total += test.SweatRateLPerHour
+	}
+	return total / float64(len(matching)), len(matching)
+}
+
+func (s *SweatTestService) fetchUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).First(&user, "id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, fmt.Errorf("fetch user: %w", err)
+	}
+	return &user, nil
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const defaultSyncPageSize = 1000
+
+var ErrInvalidSyncCursor = errors.New("invalid sync cursor")
+
+// SyncService lets multiple devices converge on the same state by replaying the sync journal.
+type SyncService struct {
+	db *gorm.DB
+}
+
+func NewSyncService(db *gorm.DB) *SyncService {
+	return &SyncService{db: db}
+}
+
+// Changes returns everything that changed after cursor. An empty cursor yields a full snapshot
+// together with a cursor the client should send on its next call.
+func (s *SyncService) Changes(ctx context.Context, userID uuid.UUID, cursor string, privacyVersion, termsVersion string) (*dto.SyncResponse, error) {
+	if strings.TrimSpace(cursor) == "" {
+		return s.snapshot(ctx, userID, privacyVersion, termsVersion)
+	}
+
+	after, err := strconv.ParseUint(cursor, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidSyncCursor
+	}
+
+	var changes []models.SyncChange
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ? AND sequence > ?", userID, after).
+		Order("sequence ASC").
+		Limit(defaultSyncPageSize + 1).
+		Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("fetch sync changes: %w", err)
+	}
+
+	hasMore := len(changes) > defaultSyncPageSize
+	if hasMore {
+		changes = changes[:defaultSyncPageSize]
+	}
+
+	nextCursor := after
+	if len(changes) > 0 {
+		nextCursor = changes[len(changes)-1].Sequence
+	}
+
+	// Only the latest operation per entity matters within a page.
+	type entityKey struct {
+		entityType string
+		id         uuid.UUID
+	}
+	latest := make(map[entityKey]models.SyncChange, len(changes))
+	order := make([]entityKey, 0, len(changes))
+	for _, change := range changes {
+		key := entityKey{entityType: change.EntityType, id: change.EntityID}
+		if _, seen := latest[key]; !seen {
+			order = append(order, key)
+		}
+		latest[key] = change
+	}
+
+	response := &dto.SyncResponse{
+		Cursor:        strconv.FormatUint(nextCursor, 10),
+		HasMore:       hasMore,
+		Drinks:        []dto.DrinkResponse{},
+		HydrationLogs: []dto.HydrationLogResponse{},
+		DailyGoals:    []dto.DailyGoalResponse{},
+		Tombstones:    []dto.SyncTombstone{},
+	}
+
+	upserts := make(map[string][]uuid.UUID)
+	for _, key := range order {
+		change := latest[key]
+		if change.Operation == models.SyncOperationDelete {
+			response.Tombstones = append(response.Tombstones, dto.SyncTombstone{
+				EntityType: change.EntityType,
+				ID:         change.EntityID,
+				DeletedAt:  change.CreatedAt,
+			})
+			continue
+		}
+		upserts[change.EntityType] = append(upserts[change.EntityType], change.EntityID)
+	}
+
+	if len(upserts[models.SyncEntityProfile]) > 0 {
+		var user models.User
+		if err := s.db.WithContext(ctx).First(&user, "id = ?", userID).Error; err != nil {
+			return nil, fmt.Errorf("fetch user: %w", err)
+		}
+		profile := dto.NewUserResponse(user, privacyVersion, termsVersion)
+		response.Profile = &profile
+	}
+
+	if ids := upserts[models.SyncEntityDrink]; len(ids) > 0 {
+		var drinks []models.Drink
+		if err := s.db.WithContext(ctx).Where("user_id = ? AND id IN ?", userID, ids).Find(&drinks).Error; err != nil {
+			return nil, fmt.Errorf("fetch changed drinks: %w", err)
+		}
+		for _, drink := range drinks {
+			response.Drinks = append(response.Drinks, dto.NewDrinkResponse(drink))
+		}
+	}
+
+	if ids := upserts[models.SyncEntityHydrationLog]; len(ids) > 0 {
+		var logs []models.HydrationLog
+		if err := s.db.WithContext(ctx).Where("user_id = ? AND id IN ?", userID, ids).Order("consumed_at ASC").Find(&logs).Error; err != nil {
+			return nil, fmt.Errorf("fetch changed hydration logs: %w", err)
+		}
+		for _, logEntry := range logs {
+			response.HydrationLogs = append(response.HydrationLogs, dto.NewHydrationLogResponse(logEntry))
+		}
+	}
+
+	if ids := upserts[models.SyncEntityDailyGoal]; len(ids) > 0 {
+		var goals []models.DailyGoal
+		if err := s.db.WithContext(ctx).Where("user_id = ? AND id IN ?", userID, ids).Order("date ASC").Find(&goals).Error; err != nil {
+			return nil, fmt.Errorf("fetch changed daily goals: %w", err)
+		}
+		for _, goal := range goals {
+			response.DailyGoals = append(response.DailyGoals, dto.NewDailyGoalResponse(goal))
+		}
+	}
+
+	return response, nil
+}
+
+// snapshot reads the user's data and the cursor from one REPEATABLE READ snapshot, so the
+// cursor is exactly the last journal entry whose write the snapshot contains.
+func (s *SyncService) snapshot(ctx context.Context, userID uuid.UUID, privacyVersion, termsVersion string) (*dto.SyncResponse, error) {
+	var user models.User
+	var drinks []models.Drink
+	var logs []models.HydrationLog
+	var goals []models.DailyGoal
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&user, "id = ?", userID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("user not found")
+			}
+			return fmt.Errorf("fetch user: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Order("created_at ASC").Find(&drinks).Error; err != nil {
+			return fmt.Errorf("fetch drinks: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Order("consumed_at ASC").Find(&logs).Error; err != nil {
+			return fmt.Errorf("fetch hydration logs: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Order("date ASC").Find(&goals).Error; err != nil {
+			return fmt.Errorf("fetch daily goals: %w", err)
+		}
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	cursor := user.SyncSequence
+
+	profile := dto.NewUserResponse(user, privacyVersion, termsVersion)
+	response := &dto.SyncResponse{
+		Cursor:        strconv.FormatUint(cursor, 10),
+		FullSnapshot:  true,
+		Profile:       &profile,
+		Drinks:        make([]dto.DrinkResponse, 0, len(drinks)),
+		HydrationLogs: make([]dto.HydrationLogResponse, 0, len(logs)),
+		DailyGoals:    make([]dto.DailyGoalResponse, 0, len(goals)),
+		Tombstones:    []dto.SyncTombstone{},
+	}
+	for _, drink := range drinks {
+		response.Drinks = append(response.Drinks, dto.NewDrinkResponse(drink))
+	}
+	for _, logEntry := range logs {
+		response.HydrationLogs = append(response.HydrationLogs, dto.NewHydrationLogResponse(logEntry))
+	}
+	for _, goal := range goals {
+		response.DailyGoals = append(response.DailyGoals, dto.NewDailyGoalResponse(goal))
+	}
+
+	return response, nil
+}
+
+// recordSyncChange appends a journal entry. Callers pass their transaction so the journal
+// and the entity write commit or roll back together. Taking the next sequence locks the user's
+// row until that transaction ends, so concurrent writers for one user commit in sequence order.
+func recordSyncChange(db *gorm.DB, userID uuid.UUID, entityType string, entityID uuid.UUID, operation string) error {
+	var sequence uint64
+	result := db.Raw("UPDATE users SET sync_sequence = sync_sequence + 1 WHERE id = ? RETURNING sync_sequence", userID).Scan(&sequence)
+	if result.Error != nil {
+		return fmt.Errorf("advance sync sequence: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("advance sync sequence: user not found")
+	}
+
+	change := models.SyncChange{
+		UserID:     userID,
+		Sequence:   sequence,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Operation:  operation,
+	}
+	if err := db.Create(&change).Error; err != nil {
+		return fmt.Errorf("record sync change: %w", err)
+	}
+	return nil
+}
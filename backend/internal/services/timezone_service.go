@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/AD-Archer/archer-aqua/backend/internal/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Timezone change sources.
+const (
+	TimezoneSourceProfile    = "profile"
+	TimezoneSourceCorrection = "correction"
+)
+
+var ErrInvalidRekeyRange = errors.New("from must be before to")
+
+// errRekeyPreview rolls back a rekey that was only previewed.
+var errRekeyPreview = errors.New("rekey preview")
+
+// TimezoneService exposes a user's timezone history and corrects logs recorded in the wrong zone.
+type TimezoneService struct {
+	db *gorm.DB
+}
+
+func NewTimezoneService(db *gorm.DB) *TimezoneService {
+	return &TimezoneService{db: db}
+}
+
+// History lists the user's timezone changes, newest first.
+func (s *TimezoneService) History(ctx context.Context, userID uuid.UUID) ([]models.TimezoneChange, error) {
+	var changes []models.TimezoneChange
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("effective_at DESC").
+		Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("list timezone history: %w", err)
+	}
+	return changes, nil
+}
+
+// Rekey previews or applies moving entries in a time range onto a corrected timezone: logs,
+// including trashed ones, and everything else rekeyUserDays keys by day. With apply false
+// nothing is written and the response describes what would change.
+func (s *TimezoneService) Rekey(ctx context.Context, userID uuid.UUID, input dto.TimezoneRekeyRequest, apply bool) (*dto.TimezoneRekeyResponse, error) {
+	if strings.TrimSpace(input.Timezone) == "" {
+		return nil, fmt.Errorf("timezone is required")
+	}
+	loc, err := utils.LoadLocation(strings.TrimSpace(input.Timezone))
+	if err != nil {
+		return nil, err
+	}
+
+	to := time.Now().UTC()
+	if input.To != nil {
+		to = input.To.UTC()
+	}
+	from := input.From.UTC()
+	if !from.Before(to) {
+		return nil, ErrInvalidRekeyRange
+	}
+
+	var user models.User
+	if err := s.db.WithContext(ctx).First(&user, "id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, fmt.Errorf("fetch user: %w", err)
+	}
+
+	window := &rekeyWindow{From: from, To: to, Timezone: loc.String()}
+	if input.FromTimezone != nil {
+		window.FromTimezone = strings.TrimSpace(*input.FromTimezone)
+	}
+
+	// A preview runs the same rekey and rolls it back, so it reports exactly what applying would.
+	var result *rekeyResult
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if result, err = rekeyUserDays(tx, &user, window); err != nil {
+			return err
+		}
+		if !apply {
+			return errRekeyPreview
+		}
+		if len(result.Logs) == 0 && len(result.Sessions) == 0 {
+			return nil
+		}
+		return recordTimezoneChange(tx, userID, window.FromTimezone, loc.String(), from, TimezoneSourceCorrection)
+	})
+	if err != nil && !errors.Is(err, errRekeyPreview) {
+		return nil, err
+	}
+
+	response := &dto.TimezoneRekeyResponse{
+		Applied:          apply,
+		AffectedLogs:     len(result.Logs),
+		AffectedSessions: len(result.Sessions),
+		ChangedDays:      result.ChangedDays,
+		Changes:          make([]dto.TimezoneRekeyChange, 0, len(result.Logs)),
+	}
+	for _, entry := range result.Logs {
+		response.Changes = append(response.Changes, dto.TimezoneRekeyChange{
+			LogID:       entry.ID,
+			ConsumedAt:  entry.RecordedAt,
+			OldTimezone: entry.OldTimezone,
+			NewTimezone: entry.NewTimezone,
+			OldDailyKey: entry.OldKey,
+			NewDailyKey: entry.NewKey,
+		})
+	}
+
+	return response, nil
+}
+
+// timezoneOnDate returns the timezone the user was in on a daily key: the zone the day's first
+// log was recorded in, otherwise the latest timezone change effective by the end of the day,
+// otherwise the profile timezone. The history lookup compares against midnight UTC after the
+// day, so a move on that very day may resolve to either zone; days with logs are exact.
+func timezoneOnDate(tx *gorm.DB, user *models.User, date string) (*time.Location, error) {
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return nil, fmt.Errorf("parse date: %w", err)
+	}
+
+	var zones []string
+	if err := tx.Model(&models.HydrationLog{}).
+		Where("user_id = ? AND daily_key = ? AND timezone <> ''", user.ID, date).
+		Order("consumed_at ASC").
+		Limit(1).
+		Pluck("timezone", &zones).Error; err != nil {
+		return nil, fmt.Errorf("fetch day timezone: %w", err)
+	}
+	if len(zones) == 0 {
+		if err := tx.Model(&models.TimezoneChange{}).
+			Where("user_id = ? AND effective_at < ? AND timezone <> ''", user.ID, day.AddDate(0, 0, 1)).
+			Order("effective_at DESC").
+			Limit(1).
+			Pluck("timezone", &zones).Error; err != nil {
+			return nil, fmt.Errorf("fetch timezone history: %w", err)
+		}
+	}
+	if len(zones) == 0 {
+		return utils.LoadLocation(user.Timezone)
+	}
+	return utils.LoadLocation(zones[0])
+}
+
+// recordTimezoneChange appends an entry to the user's timezone history.
+func recordTimezoneChange(tx *gorm.DB, userID uuid.UUID, previous, timezone string, effectiveAt time.Time, source string) error {
+	change := models.TimezoneChange{
+		UserID:           userID,
+		Timezone:         timezone,
+		PreviousTimezone: previous,
+		EffectiveAt:      effectiveAt.UTC(),
+		Source:           source,
+	}
+	if err := tx.Create(&change).Error; err != nil {
+		return fmt.Errorf("record timezone change: %w", err)
+	}
+	return nil
+}
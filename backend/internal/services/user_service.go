@@ -11,9 +11,16 @@ import (
 	"github.com/AD-Archer/archer-aqua/backend/internal/models"
 	"github.com/AD-Archer/archer-aqua/backend/internal/utils"
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
+// maxDayStartOffsetMinutes limits how late a user's day may begin (noon).
+const maxDayStartOffsetMinutes = 12 * 60
+
+// minutesPerDay bounds wake and sleep times, which are stored as minutes after local midnight.
+const minutesPerDay = 24 * 60
+
 // UserService manages hydration user profiles.
 type UserService struct {
 	db *gorm.DB
@@ -51,10 +58,6 @@ func (s *UserService) CreateUser(ctx context.Context, input dto.CreateUserReques
 	}
 
 	now := time.Now().UTC()
-	dailyGoal := CalculateDailyGoalLiters(weightKg, input.ActivityLevel, input.Gender)
-	if input.CustomGoalLiters != nil && *input.CustomGoalLiters > 0 {
-		dailyGoal = *input.CustomGoalLiters
-	}
 
 	var user models.User
 	result := s.db.WithContext(ctx).Where("email = ?", email).First(&user)
@@ -64,6 +67,8 @@ func (s *UserService) CreateUser(ctx context.Context, input dto.CreateUserReques
 		}
 		user = models.User{Email: email}
 	}
+	previousTimezone := user.Timezone
+	previousWeightKg := user.WeightKg
 
 	user.DisplayName = defaultString(input.DisplayName, user.DisplayName)
 	user.WeightKg = weightKg
@@ -78,15 +83,43 @@ func (s *UserService) CreateUser(ctx context.Context, input dto.CreateUserReques
 	user.LocationCountry = input.Location.Country
 	user.LocationLatitude = input.Location.Latitude
 	user.LocationLongitude = input.Location.Longitude
-	user.DailyGoalLiters = dailyGoal
 	user.CustomGoalLiters = input.CustomGoalLiters
+	user.GoalStrategy = input.GoalStrategy
+	if user.GoalStrategy == "" {
+		user.GoalStrategy = GoalStrategyWeightBased
+		if input.CustomGoalLiters != nil && *input.CustomGoalLiters > 0 {
+			user.GoalStrategy = GoalStrategyCustom
+		}
+	}
+	if err := applyGoalStrategy(&user); err != nil {
+		return nil, err
+	}
 	user.VolumeUnit = defaultString(input.VolumeUnit, "ml")
 	user.TemperatureUnit = defaultString(input.TemperatureUnit, "c")
 	user.ProgressWheelStyle = defaultString(input.ProgressWheelStyle, defaultString(user.ProgressWheelStyle, "drink_colors"))
 	user.WeatherAdjustmentsEnabled = input.WeatherAdjustmentsEnabled
 
-	if err := s.db.WithContext(ctx).Save(&user).Error; err != nil {
-		return nil, fmt.Errorf("save user: %w", err)
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&user).Error; err != nil {
+			return fmt.Errorf("save user: %w", err)
+		}
+		if user.WeightKg > 0 && user.WeightKg != previousWeightKg {
+			if err := recordWeightMeasurement(tx, &user, now); err != nil {
+				return err
+			}
+		}
+		if err := resnapshotUpcomingGoals(tx, user.ID); err != nil {
+			return err
+		}
+		if user.Timezone != previousTimezone {
+			if err := recordTimezoneChange(tx, user.ID, previousTimezone, user.Timezone, now, TimezoneSourceProfile); err != nil {
+				return err
+			}
+		}
+		return recordSyncChange(tx, user.ID, models.SyncEntityProfile, user.ID, models.SyncOperationUpsert)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &user, nil
@@ -101,6 +134,7 @@ func (s *UserService) UpdateUser(ctx context.Context, userID uuid.UUID, input dt
 	if input.DisplayName != nil {
 		user.DisplayName = *input.DisplayName
 	}
+	previousWeightKg := user.WeightKg
 	if input.Weight != nil {
 		weightKg, err := utils.ConvertWeightToKg(input.Weight.Value, input.Weight.Unit)
 		if err != nil {
@@ -118,11 +152,13 @@ func (s *UserService) UpdateUser(ctx context.Context, userID uuid.UUID, input dt
 	if input.ActivityLevel != nil {
 		user.ActivityLevel = *input.ActivityLevel
 	}
+	previousTimezone := user.Timezone
 	if input.Timezone != nil {
 		loc, err := utils.LoadLocation(*input.Timezone)
 		if err != nil {
 			return nil, err
 		}
+		// Existing logs keep the zone they were recorded in; see TimezoneService.Rekey for corrections.
 		user.Timezone = loc.String()
 		now := time.Now().UTC()
 		user.TimezoneLastConfirmedAt = &now
@@ -143,30 +179,167 @@ func (s *UserService) UpdateUser(ctx context.Context, userID uuid.UUID, input dt
 	if input.ProgressWheelStyle != nil {
 		user.ProgressWheelStyle = *input.ProgressWheelStyle
 	}
+	weatherToggled := false
 	if input.WeatherAdjustmentsEnabled != nil {
+		weatherToggled = user.WeatherAdjustmentsEnabled != *input.WeatherAdjustmentsEnabled
 		user.WeatherAdjustmentsEnabled = *input.WeatherAdjustmentsEnabled
 	}
 	if input.CustomGoalLiters != nil {
 		user.CustomGoalLiters = input.CustomGoalLiters
+		// Setting or clearing a custom target implies the strategy unless one is given explicitly.
+		if input.GoalStrategy == nil {
+			if *input.CustomGoalLiters > 0 {
+				user.GoalStrategy = GoalStrategyCustom
+			} else if user.GoalStrategy == GoalStrategyCustom {
+				user.GoalStrategy = GoalStrategyWeightBased
+			}
+		}
+	}
+	if input.GoalStrategy != nil {
+		user.GoalStrategy = *input.GoalStrategy
+	}
+	if input.DayStartOffsetMinutes != nil {
+		offset := *input.DayStartOffsetMinutes
+		if offset < 0 || offset > maxDayStartOffsetMinutes {
+			return nil, fmt.Errorf("dayStartOffsetMinutes must be between 0 and %d", maxDayStartOffsetMinutes)
+		}
+		if offset != user.DayStartOffsetMinutes {
+			// Existing logs are re-keyed by the background rekey job.
+			user.DayStartOffsetMinutes = offset
+			user.DayStartRekeyPending = true
+		}
+	}
+	restrictionToggled := false
+	if input.FluidRestrictionEnabled != nil {
+		restrictionToggled = user.FluidRestrictionEnabled != *input.FluidRestrictionEnabled
+		user.FluidRestrictionEnabled = *input.FluidRestrictionEnabled
+	}
+	sweatRateToggled := false
+	if input.SweatRateGoalsEnabled != nil {
+		sweatRateToggled = user.SweatRateGoalsEnabled != *input.SweatRateGoalsEnabled
+		user.SweatRateGoalsEnabled = *input.SweatRateGoalsEnabled
+	}
+	if input.CaffeineLimitMg != nil {
+		user.CaffeineLimitMg = positiveOrNil(*input.CaffeineLimitMg)
+	}
+	if input.AlcoholLimitUnits != nil {
+		user.AlcoholLimitUnits = positiveOrNil(*input.AlcoholLimitUnits)
+	}
+	if input.CaffeineHalfLifeMinutes != nil {
+		if *input.CaffeineHalfLifeMinutes < minCaffeineHalfLifeMinutes || *input.CaffeineHalfLifeMinutes > maxCaffeineHalfLifeMinutes {
+			return nil, fmt.Errorf("caffeineHalfLifeMinutes must be between %d and %d", minCaffeineHalfLifeMinutes, maxCaffeineHalfLifeMinutes)
+		}
+		user.CaffeineHalfLifeMinutes = *input.CaffeineHalfLifeMinutes
+	}
+	if input.CaffeineBedtimeLimitMg != nil {
+		if *input.CaffeineBedtimeLimitMg < 0 {
+			return nil, fmt.Errorf("caffeineBedtimeLimitMg cannot be negative")
+		}
+		user.CaffeineBedtimeLimitMg = *input.CaffeineBedtimeLimitMg
+	}
+	if input.WakeTimeMinutes != nil {
+		user.WakeTimeMinutes = *input.WakeTimeMinutes
+	}
+	if input.SleepTimeMinutes != nil {
+		user.SleepTimeMinutes = *input.SleepTimeMinutes
+	}
+	if input.WakeTimeMinutes != nil || input.SleepTimeMinutes != nil {
+		if user.WakeTimeMinutes < 0 || user.WakeTimeMinutes >= minutesPerDay || user.SleepTimeMinutes < 0 || user.SleepTimeMinutes >= minutesPerDay {
+			return nil, fmt.Errorf("wakeTimeMinutes and sleepTimeMinutes must be between 0 and %d", minutesPerDay-1)
+		}
+		if user.WakeTimeMinutes == user.SleepTimeMinutes {
+			return nil, fmt.Errorf("wake and sleep times must differ")
+		}
 	}
 
-	user.DailyGoalLiters = CalculateDailyGoalLiters(user.WeightKg, user.ActivityLevel, user.Gender)
-	if user.CustomGoalLiters != nil && *user.CustomGoalLiters > 0 {
-		user.DailyGoalLiters = *user.CustomGoalLiters
+	previousGoalLiters := user.DailyGoalLiters
+	if err := applyGoalStrategy(user); err != nil {
+		return nil, err
 	}
+	goalChanged := user.DailyGoalLiters != previousGoalLiters || weatherToggled || restrictionToggled || sweatRateToggled
 
-	if err := s.db.WithContext(ctx).Save(user).Error; err != nil {
-		return nil, fmt.Errorf("update user: %w", err)
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(user).Error; err != nil {
+			return fmt.Errorf("update user: %w", err)
+		}
+		if user.WeightKg > 0 && user.WeightKg != previousWeightKg {
+			// Recorded before re-snapshotting so today's goal uses the new weight.
+			if err := recordWeightMeasurement(tx, user, time.Now()); err != nil {
+				return err
+			}
+		}
+		if goalChanged {
+			if err := resnapshotUpcomingGoals(tx, user.ID); err != nil {
+				return err
+			}
+		}
+		if sweatRateToggled {
+			// Today's and planned sessions switch between the sweat test rate and the estimates.
+			loc, err := utils.LoadLocation(user.Timezone)
+			if err != nil {
+				return err
+			}
+			if err := refreshExerciseGoals(tx, user, utils.DailyKey(time.Now(), loc, user.DayStartOffsetMinutes)); err != nil {
+				return err
+			}
+		}
+		if restrictionToggled {
+			// Stored day statuses switch between target and limit wording.
+			if err := rebuildUserRollups(tx, user.ID); err != nil {
+				return err
+			}
+		}
+		if user.Timezone != previousTimezone {
+			if err := recordTimezoneChange(tx, user.ID, previousTimezone, user.Timezone, time.Now(), TimezoneSourceProfile); err != nil {
+				return err
+			}
+		}
+		return recordSyncChange(tx, user.ID, models.SyncEntityProfile, user.ID, models.SyncOperationUpsert)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return user, nil
 }
 
+// applyGoalStrategy recomputes DailyGoalLiters with the user's selected strategy and records
+// the strategy inputs next to it. An empty strategy falls back to the weight-based formula.
+func applyGoalStrategy(user *models.User) error {
+	name := user.GoalStrategy
+	if strings.TrimSpace(name) == "" {
+		name = GoalStrategyWeightBased
+	}
+	strategy, err := LookupGoalStrategy(name)
+	if err != nil {
+		return err
+	}
+
+	computation, err := strategy.Compute(GoalProfile{
+		WeightKg:         user.WeightKg,
+		Age:              user.Age,
+		Gender:           user.Gender,
+		ActivityLevel:    user.ActivityLevel,
+		CustomGoalLiters: user.CustomGoalLiters,
+	})
+	if err != nil {
+		return err
+	}
+
+	user.GoalStrategy = strategy.Name()
+	user.GoalStrategyInputs = datatypes.JSONMap(computation.Inputs)
+	user.DailyGoalLiters = computation.Liters
+	return nil
+}
+
 func (s *UserService) DeleteUser(ctx context.Context, userID uuid.UUID) error {
 	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Delete(&models.User{}, "id = ?", userID).Error; err != nil {
 			return fmt.Errorf("delete user: %w", err)
 		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.SyncChange{}).Error; err != nil {
+			return fmt.Errorf("delete sync journal: %w", err)
+		}
 		return nil
 	})
 }
@@ -203,12 +376,33 @@ func (s *UserService) ExportUserData(ctx context.Context, userID uuid.UUID, priv
 		logResponses = append(logResponses, dto.NewHydrationLogResponse(logEntry))
 	}
 
+	var rollups []models.DailyRollup
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("daily_key ASC").
+		Find(&rollups).Error; err != nil {
+		return nil, fmt.Errorf("export daily totals: %w", err)
+	}
+
+	dayResponses := make([]dto.DailyTotalExport, 0, len(rollups))
+	for _, rollup := range rollups {
+		dayResponses = append(dayResponses, dto.DailyTotalExport{
+			Date:             rollup.DailyKey,
+			TotalVolumeMl:    rollup.TotalVolumeMl,
+			TotalEffectiveMl: rollup.TotalEffectiveMl,
+			GoalMl:           rollup.GoalMl,
+			Status:           rollup.Status,
+			OverLimit:        user.FluidRestrictionEnabled && rollup.TotalEffectiveMl > rollup.GoalMl,
+		})
+	}
+
 	userResponse := dto.NewUserResponse(*user, privacyVersion, termsVersion)
 
 	return &dto.UserDataExport{
 		User:            userResponse,
 		Drinks:          drinkResponses,
 		HydrationLogs:   logResponses,
+		DailyTotals:     dayResponses,
 		ExportedAt:      time.Now().UTC(),
 		PoliciesVersion: privacyVersion, // For backward compatibility
 	}, nil
@@ -217,6 +411,11 @@ func (s *UserService) ExportUserData(ctx context.Context, userID uuid.UUID, priv
 func (s *UserService) ImportUserData(ctx context.Context, userID uuid.UUID, payload dto.UserDataImportRequest) error {
 	trim := func(value string) string { return strings.TrimSpace(value) }
 
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
 	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		replace := true
 		if payload.ReplaceExisting != nil {
@@ -224,12 +423,31 @@ func (s *UserService) ImportUserData(ctx context.Context, userID uuid.UUID, payl
 		}
 
 		if replace {
-			if err := tx.Where("user_id = ?", userID).Delete(&models.HydrationLog{}).Error; err != nil {
+			var logIDs, drinkIDs []uuid.UUID
+			if err := tx.Unscoped().Model(&models.HydrationLog{}).Where("user_id = ?", userID).Pluck("id", &logIDs).Error; err != nil {
+				return fmt.Errorf("collect hydration logs: %w", err)
+			}
+			if err := tx.Model(&models.Drink{}).Where("user_id = ?", userID).Pluck("id", &drinkIDs).Error; err != nil {
+				return fmt.Errorf("collect drinks: %w", err)
+			}
+
+			if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.HydrationLog{}).Error; err != nil {
 				return fmt.Errorf("clear hydration logs: %w", err)
 			}
 			if err := tx.Where("user_id = ?", userID).Delete(&models.Drink{}).Error; err != nil {
 				return fmt.Errorf("clear drinks: %w", err)
 			}
+
+			for _, id := range logIDs {
+				if err := recordSyncChange(tx, userID, models.SyncEntityHydrationLog, id, models.SyncOperationDelete); err != nil {
+					return err
+				}
+			}
+			for _, id := range drinkIDs {
+				if err := recordSyncChange(tx, userID, models.SyncEntityDrink, id, models.SyncOperationDelete); err != nil {
+					return err
+				}
+			}
 		}
 
 		for _, drink := range payload.Drinks {
@@ -254,6 +472,9 @@ func (s *UserService) ImportUserData(ctx context.Context, userID uuid.UUID, payl
 			if err := tx.Create(&drinkModel).Error; err != nil {
 				return fmt.Errorf("import drink %s: %w", drink.ID, err)
 			}
+			if err := recordSyncChange(tx, userID, models.SyncEntityDrink, drinkModel.ID, models.SyncOperationUpsert); err != nil {
+				return err
+			}
 		}
 
 		for _, logEntry := range payload.HydrationLogs {
@@ -280,18 +501,31 @@ func (s *UserService) ImportUserData(ctx context.Context, userID uuid.UUID, payl
 				DailyKey:            logEntry.DailyKey,
 				Source:              defaultString(trim(logEntry.Source), "manual"),
 				Notes:               logEntry.Notes,
+				IdempotencyKey:      normalizeIdempotencyKey(logEntry.IdempotencyKey),
 			}
 
 			if entry.EffectiveMl <= 0 {
 				entry.EffectiveMl = entry.VolumeMl * entry.HydrationMultiplier
 			}
 
+			// Re-derive the day from the instant so imports honor the user's day boundary.
+			loc, err := utils.LoadLocation(entry.Timezone)
+			if err != nil {
+				loc = time.UTC
+			}
+			entry.Timezone = loc.String()
+			entry.ConsumedAtLocal = entry.ConsumedAt.In(loc)
+			entry.DailyKey = utils.DailyKey(entry.ConsumedAt, loc, user.DayStartOffsetMinutes)
+
 			if err := tx.Create(&entry).Error; err != nil {
 				return fmt.Errorf("import hydration log %s: %w", logEntry.ID, err)
 			}
+			if err := recordSyncChange(tx, userID, models.SyncEntityHydrationLog, entry.ID, models.SyncOperationUpsert); err != nil {
+				return err
+			}
 		}
 
-		return nil
+		return rebuildUserRollups(tx, userID)
 	})
 }
 
@@ -301,3 +535,11 @@ func defaultString(value, fallback string) string {
 	}
 	return value
 }
+
+// positiveOrNil treats zero or negative optional limits as "no limit".
+func positiveOrNil(value float64) *float64 {
+	if value <= 0 {
+		return nil
+	}
+	return &value
+}
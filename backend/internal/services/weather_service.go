@@ -7,6 +7,7 @@ import (
 
 	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
 	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/AD-Archer/archer-aqua/backend/internal/utils"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -67,8 +68,14 @@ func (s *WeatherService) SaveWeatherData(ctx context.Context, userID uuid.UUID,
 		existingWeather.ExpiresAt = expiresAt
 		existingWeather.RefreshCount++
 
-		if err := s.db.WithContext(ctx).Save(&existingWeather).Error; err != nil {
-			return nil, fmt.Errorf("update weather data: %w", err)
+		err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Save(&existingWeather).Error; err != nil {
+				return fmt.Errorf("update weather data: %w", err)
+			}
+			return refreshWeatherGoal(tx, userID, &existingWeather)
+		})
+		if err != nil {
+			return nil, err
 		}
 
 		return &existingWeather, nil
@@ -96,13 +103,52 @@ func (s *WeatherService) SaveWeatherData(ctx context.Context, userID uuid.UUID,
 		RefreshCount:  1,
 	}
 
-	if err := s.db.WithContext(ctx).Create(&weather).Error; err != nil {
-		return nil, fmt.Errorf("create weather data: %w", err)
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&weather).Error; err != nil {
+			return fmt.Errorf("create weather data: %w", err)
+		}
+		return refreshWeatherGoal(tx, userID, &weather)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &weather, nil
 }
 
+// refreshWeatherGoal keeps the reading as today's DailyWeather when it is the hottest so far and,
+// if that changed and weather adjustments are enabled, rewrites today's weather bonus. It runs
+// in the transaction that saved the weather so the two cannot diverge.
+func refreshWeatherGoal(tx *gorm.DB, userID uuid.UUID, reading *models.WeatherData) error {
+	var user models.User
+	if err := tx.First(&user, "id = ?", userID).Error; err != nil {
+		return fmt.Errorf("fetch user: %w", err)
+	}
+
+	loc, err := utils.LoadLocation(user.Timezone)
+	if err != nil {
+		return err
+	}
+	today := utils.DailyKey(reading.FetchedAt, loc, user.DayStartOffsetMinutes)
+
+	result := tx.Exec(`
+		INSERT INTO daily_weathers (id, created_at, updated_at, user_id, daily_key, temperature, feels_like, humidity, fetched_at)
+		VALUES (?, NOW(), NOW(), ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (user_id, daily_key) DO UPDATE
+		SET temperature = EXCLUDED.temperature, feels_like = EXCLUDED.feels_like, humidity = EXCLUDED.humidity,
+			fetched_at = EXCLUDED.fetched_at, updated_at = NOW()
+		WHERE GREATEST(EXCLUDED.temperature, EXCLUDED.feels_like) > GREATEST(daily_weathers.temperature, daily_weathers.feels_like)
+	`, uuid.New(), userID, today, reading.Temperature, reading.FeelsLike, reading.Humidity, reading.FetchedAt)
+	if result.Error != nil {
+		return fmt.Errorf("record daily weather: %w", result.Error)
+	}
+	if result.RowsAffected == 0 || !user.WeatherAdjustmentsEnabled {
+		return nil
+	}
+
+	return refreshGoalAdjustments(tx, &user, today)
+}
+
 // GetWeatherHistory returns historical weather data for a user
 func (s *WeatherService) GetWeatherHistory(ctx context.Context, userID uuid.UUID, limit int) ([]models.WeatherData, error) {
 	var weatherData []models.WeatherData
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AD-Archer/archer-aqua/backend/internal/dto"
+	"github.com/AD-Archer/archer-aqua/backend/internal/models"
+	"github.com/AD-Archer/archer-aqua/backend/internal/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Weight measurement sources. Profile entries are recorded when the weight is edited on the
+// profile itself.
+const (
+	WeightSourceManual  = "manual"
+	WeightSourceProfile = "profile"
+)
+
+var ErrWeightMeasurementNotFound = errors.New("weight measurement not found")
+
+// WeightService manages the body weight history. The latest measurement is mirrored onto the
+// profile so the profile goal and everything reading User.WeightKg follow it; goals for earlier
+// days use the measurement in effect on that day (see profileOnDate).
+type WeightService struct {
+	db *gorm.DB
+}
+
+func NewWeightService(db *gorm.DB) *WeightService {
+	return &WeightService{db: db}
+}
+
+// ListMeasurements returns measurements between two inclusive daily keys, newest first;
+// empty bounds are open.
+func (s *WeightService) ListMeasurements(ctx context.Context, userID uuid.UUID, from, to string) ([]models.WeightMeasurement, error) {
+	query := s.db.WithContext(ctx).Where("user_id = ?", userID)
+	if from != "" {
+		query = query.Where("daily_key >= ?", from)
+	}
+	if to != "" {
+		query = query.Where("daily_key <= ?", to)
+	}
+
+	var measurements []models.WeightMeasurement
+	if err := query.Order("measured_at DESC, created_at DESC").Find(&measurements).Error; err != nil {
+		return nil, fmt.Errorf("list weight measurements: %w", err)
+	}
+	return measurements, nil
+}
+
+func (s *WeightService) AddMeasurement(ctx context.Context, userID uuid.UUID, input dto.CreateWeightMeasurementRequest) (*models.WeightMeasurement, error) {
+	user, err := s.fetchUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	unit := defaultString(input.Weight.Unit, "kg")
+	weightKg, err := utils.ConvertWeightToKg(input.Weight.Value, unit)
+	if err != nil {
+		return nil, err
+	}
+	if weightKg <= 0 {
+		return nil, fmt.Errorf("weight must be positive")
+	}
+
+	measuredAt := time.Now().UTC()
+	if input.MeasuredAt != nil {
+		if input.MeasuredAt.After(measuredAt) {
+			return nil, fmt.Errorf("measuredAt cannot be in the future")
+		}
+		measuredAt = input.MeasuredAt.UTC()
+	}
+
+	loc, err := utils.LoadLocation(defaultString(input.Timezone, user.Timezone))
+	if err != nil {
+		return nil, err
+	}
+
+	measurement := models.WeightMeasurement{
+		UserID:     userID,
+		WeightKg:   weightKg,
+		Unit:       unit,
+		MeasuredAt: measuredAt,
+		Timezone:   loc.String(),
+		DailyKey:   utils.DailyKey(measuredAt, loc, user.DayStartOffsetMinutes),
+		Source:     WeightSourceManual,
+		Notes:      input.Notes,
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&measurement).Error; err != nil {
+			return fmt.Errorf("create weight measurement: %w", err)
+		}
+		return syncProfileWeight(tx, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &measurement, nil
+}
+
+// DeleteMeasurement removes a measurement. Deleting the latest one moves the profile back to
+// the one before it; deleting the only one leaves the profile weight as it is.
+func (s *WeightService) DeleteMeasurement(ctx context.Context, userID, measurementID uuid.UUID) error {
+	user, err := s.fetchUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var measurement models.WeightMeasurement
+	if err := s.db.WithContext(ctx).First(&measurement, "id = ? AND user_id = ?", measurementID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrWeightMeasurementNotFound
+		}
+		return fmt.Errorf("fetch weight measurement: %w", err)
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.WeightMeasurement{}, "id = ?", measurement.ID).Error; err != nil {
+			return fmt.Errorf("delete weight measurement: %w", err)
+		}
+		return syncProfileWeight(tx, user)
+	})
+}
+
+// recordWeightMeasurement appends the profile's current weight to the history after a profile edit.
+func recordWeightMeasurement(tx *gorm.DB, user *models.User, measuredAt time.Time) error {
+	loc, err := utils.LoadLocation(user.Timezone)
+	if err != nil {
+		return err
+	}
+	measurement := models.WeightMeasurement{
+		UserID:     user.ID,
+		WeightKg:   user.WeightKg,
+		Unit:       defaultString(user.WeightUnit, "kg"),
+		MeasuredAt: measuredAt.UTC(),
+		Timezone:   loc.String(),
+		DailyKey:   utils.DailyKey(measuredAt, loc, user.DayStartOffsetMinutes),
+		Source:     WeightSourceProfile,
+	}
+	if err := tx.Create(&measurement).Error; err != nil {
+		return fmt.Errorf("record weight measurement: %w", err)
+	}
+	return nil
+}
+
+// syncProfileWeight copies the latest measurement onto the profile, recomputes the profile goal
+// and moves today's and future snapshot goals onto it.
+func syncProfileWeight(tx *gorm.DB, user *models.User) error {
+	var latest models.WeightMeasurement
+	err := tx.Where("user_id = ?", user.ID).
+		Order("measured_at DESC, created_at DESC").
+		First(&latest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("fetch latest weight measurement: %w", err)
+	}
+
+	if latest.WeightKg != user.WeightKg || latest.Unit != user.WeightUnit {
+		user.WeightKg = latest.WeightKg
+		user.WeightUnit = latest.Unit
+		if err := applyGoalStrategy(user); err != nil {
+			return err
+		}
+		if err := tx.Save(user).Error; err != nil {
+			return fmt.Errorf("update user: %w", err)
+		}
+		if err := recordSyncChange(tx, user.ID, models.SyncEntityProfile, user.ID, models.SyncOperationUpsert); err != nil {
+			return err
+		}
+	}
+
+	// Even with the profile unchanged, a back-dated entry can change the weight in effect today.
+	return resnapshotUpcomingGoals(tx, user.ID)
+}
+
+// loadWeightHistory returns the user's measurements in the order profileOnDate expects.
+func loadWeightHistory(db *gorm.DB, userID uuid.UUID) ([]models.WeightMeasurement, error) {
+	var measurements []models.WeightMeasurement
+	if err := db.Where("user_id = ?", userID).
+		Order("daily_key ASC, measured_at ASC").
+		Find(&measurements).Error; err != nil {
+		return nil, fmt.Errorf("load weight history: %w", err)
+	}
+	return measurements, nil
+}
+
+// profileOnDate returns user as of date: with the last weight measured on or before that day and
+// the profile goal recomputed for it. Days before the first measurement use the first one, and
+// without any history the profile is returned unchanged. weights must come from loadWeightHistory.
+func profileOnDate(user *models.User, weights []models.WeightMeasurement, date string) *models.User {
+	if len(weights) == 0 {
+		return user
+	}
+
+	weightKg := weights[0].WeightKg
+	for _, measurement := range weights {
+		if measurement.DailyKey > date {
+			break
+		}
+		weightKg = measurement.WeightKg
+	}
+	if weightKg == user.WeightKg {
+		return user
+	}
+
+	profile := *user
+	profile.WeightKg = weightKg
+	if err := applyGoalStrategy(&profile); err != nil {
+		return user
+	}
+	return &profile
+}
+
+func (s *WeightService) fetchUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).First(&user, "id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, fmt.Errorf("fetch user: %w", err)
+	}
+	return &user, nil
+}
@@ -19,17 +19,22 @@ func LoadLocation(tz string) (*time.Location, error) {
 	return loc, nil
 }
 
-// DayBounds calculates the start and end instants for a local day.
-func DayBounds(date time.Time, loc *time.Location) (time.Time, time.Time) {
-	local := date.In(loc)
-	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
-	end := start.Add(24*time.Hour - time.Nanosecond)
-	return start, end
+// DayBounds calculates the start and end instants for the local day containing date.
+// dayStartMinutes shifts the boundary past midnight, e.g. 240 for a day that starts at 04:00.
+func DayBounds(date time.Time, loc *time.Location, dayStartMinutes int) (time.Time, time.Time) {
+	day, _ := time.Parse("2006-01-02", DailyKey(date, loc, dayStartMinutes))
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, dayStartMinutes, 0, 0, loc)
+	next := time.Date(day.Year(), day.Month(), day.Day()+1, 0, dayStartMinutes, 0, 0, loc)
+	return start, next.Add(-time.Nanosecond)
 }
 
-// DailyKey returns a YYYY-MM-DD string in the provided location.
-func DailyKey(t time.Time, loc *time.Location) string {
-	return t.In(loc).Format("2006-01-02")
+// DailyKey returns a YYYY-MM-DD string in the provided location for a day that begins
+// dayStartMinutes after local midnight. The shift is applied to wall-clock time so that
+// DST transitions don't move the boundary.
+func DailyKey(t time.Time, loc *time.Location, dayStartMinutes int) string {
+	local := t.In(loc)
+	wall := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute()-dayStartMinutes, local.Second(), local.Nanosecond(), time.UTC)
+	return wall.Format("2006-01-02")
 }
 
 // ConvertVolumeToMl converts a value and unit ("ml", "l", "oz") into milliliters.
@@ -62,6 +67,22 @@ func ConvertWeightToKg(value float64, unit string) (float64, error) {
 	}
 }
 
+// ConvertMassToGrams converts a food portion in g, kg, oz (avoirdupois) or lb into grams.
+func ConvertMassToGrams(value float64, unit string) (float64, error) {
+	switch unit {
+	case "g", "gram", "grams":
+		return value, nil
+	case "kg", "kilogram", "kilograms":
+		return value * 1000, nil
+	case "oz", "ounce", "ounces":
+		return value * 28.3495, nil
+	case "lb", "lbs", "pound", "pounds":
+		return value * 453.592, nil
+	default:
+		return 0, fmt.Errorf("unsupported mass unit: %s", unit)
+	}
+}
+
 // ConvertWeightFromKg converts weight from kilograms to the specified unit.
 func ConvertWeightFromKg(weightKg float64, unit string) (float64, error) {
 	switch unit {